@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// NextFunc returns a function bound to s.Next. It adapts any Sequence to
+// APIs that expect a function-valued backoff provider rather than an
+// interface.
+//
+// Example:
+//
+//	next := NextFunc(NewExponential(100*time.Millisecond, 2.0))
+//	d, ok := next()
+func NextFunc(s Sequence) func() (time.Duration, bool) {
+	return s.Next
+}
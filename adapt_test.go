@@ -0,0 +1,27 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextFunc(t *testing.T) {
+	e := NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(2))
+	next := NextFunc(e)
+
+	d1, ok1 := next()
+	d2, ok2 := next()
+	if !ok1 || !ok2 || d1 != 10*time.Millisecond || d2 != 20*time.Millisecond {
+		t.Errorf("Expected (10ms, true), (20ms, true), got (%v, %v), (%v, %v)", d1, ok1, d2, ok2)
+	}
+
+	if _, ok := next(); ok {
+		t.Error("Expected closure to report exhaustion")
+	}
+
+	// The closure is bound to the sequence state
+	e.Reset()
+	if d, _ := next(); d != 10*time.Millisecond {
+		t.Errorf("Expected closure to observe Reset(), got %v", d)
+	}
+}
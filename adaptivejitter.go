@@ -0,0 +1,106 @@
+package backoff
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// AdaptiveJitter implements a jitter strategy whose spread widens while
+// the same failure keeps recurring. Repeated identical failures suggest
+// that clients keep colliding, so delays are decorrelated more strongly
+// exactly when it is needed.
+//
+// The final delay is picked uniformly from [d * (1 - fraction), d]. The
+// fraction follows these rules:
+//   - it starts at the initial fraction
+//   - every call to SameFailure widens it by step, up to the maximum
+//   - DifferentOutcome, i.e. a success or a different error, resets it to
+//     the initial fraction
+//
+// All fractions are clamped to [0, 1]. AdaptiveJitter is safe for
+// concurrent use, so the outcome may be reported from another goroutine
+// than the one calling Next().
+type AdaptiveJitter struct {
+	initial float64
+	step    float64
+	limit   float64
+
+	mu       sync.Mutex
+	fraction float64 // current fraction
+}
+
+// NewAdaptiveJitter creates a new adaptive jitter.
+//
+// Parameters:
+//   - initial: Fraction of the delay randomized at first
+//   - step: Widening of the fraction per SameFailure call
+//   - limit: Upper limit of the fraction
+//
+// Example:
+//
+//	aj := NewAdaptiveJitter(0.1, 0.2, 1.0)
+//	b := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(aj))
+//	for {
+//		err := op()
+//		switch {
+//		case err == nil:
+//			aj.DifferentOutcome()
+//			return nil
+//		case errors.Is(err, lastErr):
+//			aj.SameFailure()
+//		default:
+//			aj.DifferentOutcome()
+//		}
+//		lastErr = err
+//		// wait for b.Next()...
+//	}
+func NewAdaptiveJitter(initial, step, limit float64) *AdaptiveJitter {
+	initial = min(max(initial, 0), 1)
+	return &AdaptiveJitter{
+		initial:  initial,
+		step:     min(max(step, 0), 1),
+		limit:    min(max(limit, 0), 1),
+		fraction: initial,
+	}
+}
+
+// Apply returns a random duration in [d * (1 - fraction), d]. If the input
+// duration is <= 0, returns 0. If the random number generator fails, the
+// input duration is returned unchanged.
+func (j *AdaptiveJitter) Apply(d time.Duration, r *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	f, ok := RandFloat(r)
+	if !ok {
+		return d
+	}
+	return d - time.Duration(float64(d)*j.Fraction()*f)
+}
+
+// SameFailure reports that the last attempt failed the same way as the
+// one before, widening the fraction by step, up to the maximum.
+func (j *AdaptiveJitter) SameFailure() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.fraction = max(min(j.fraction+j.step, j.limit), j.fraction)
+}
+
+// DifferentOutcome reports a success or a different failure, resetting
+// the fraction to its initial value.
+func (j *AdaptiveJitter) DifferentOutcome() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.fraction = j.initial
+}
+
+// Fraction returns the fraction of the delay currently randomized.
+func (j *AdaptiveJitter) Fraction() float64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.fraction
+}
+
+// String returns "adaptive".
+func (j *AdaptiveJitter) String() string { return "adaptive" }
@@ -0,0 +1,85 @@
+package backoff
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveJitter(t *testing.T) {
+	t.Run("widens on repeated failures", func(t *testing.T) {
+		aj := NewAdaptiveJitter(0.1, 0.2, 0.6)
+
+		want := []float64{0.1, 0.3, 0.5, 0.6, 0.6}
+		for i, w := range want {
+			if i > 0 {
+				aj.SameFailure()
+			}
+			if got := aj.Fraction(); got < w-1e-9 || got > w+1e-9 {
+				t.Errorf("After %d identical failures: expected fraction %.1f, got %v", i, w, got)
+			}
+		}
+
+		aj.DifferentOutcome()
+		if got := aj.Fraction(); got != 0.1 {
+			t.Errorf("Expected fraction to reset to 0.1, got %v", got)
+		}
+	})
+
+	t.Run("spread follows the fraction", func(t *testing.T) {
+		aj := NewAdaptiveJitter(0, 0.5, 1)
+		r := rand.New(rand.NewPCG(42, 1024))
+		d := time.Second
+
+		spread := func() (lowest time.Duration) {
+			lowest = d
+			for i := 0; i < 1000; i++ {
+				got := aj.Apply(d, r)
+				if got > d {
+					t.Fatalf("Jittered delay %v exceeds %v", got, d)
+				}
+				lowest = min(lowest, got)
+			}
+			return lowest
+		}
+
+		if lowest := spread(); lowest != d {
+			t.Errorf("Expected no jitter at fraction 0, lowest delay %v", lowest)
+		}
+		aj.SameFailure()
+		if lowest := spread(); lowest < d/2 || lowest > 600*time.Millisecond {
+			t.Errorf("Expected delays down to about 500ms at fraction 0.5, lowest %v", lowest)
+		}
+		aj.SameFailure()
+		if lowest := spread(); lowest > 100*time.Millisecond {
+			t.Errorf("Expected delays down to about 0 at fraction 1, lowest %v", lowest)
+		}
+	})
+
+	t.Run("with a strategy", func(t *testing.T) {
+		aj := NewAdaptiveJitter(0, 1, 1)
+		e := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(aj))
+
+		if d, _ := e.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected unjittered first delay, got %v", d)
+		}
+		aj.SameFailure()
+		if d := e.DistinctDelays(20); d < 2 {
+			t.Errorf("Expected jittered delays after identical failures, got %d distinct", d)
+		}
+	})
+
+	t.Run("clamps parameters", func(t *testing.T) {
+		aj := NewAdaptiveJitter(-1, 5, 2)
+		if got := aj.Fraction(); got != 0 {
+			t.Errorf("Expected initial fraction clamped to 0, got %v", got)
+		}
+		aj.SameFailure()
+		if got := aj.Fraction(); got != 1 {
+			t.Errorf("Expected fraction clamped to 1, got %v", got)
+		}
+		if got := aj.Apply(-time.Second, nil); got != 0 {
+			t.Errorf("Expected 0 for a negative delay, got %v", got)
+		}
+	})
+}
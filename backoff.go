@@ -1,7 +1,54 @@
 // Package backoff provides various backoff strategies for retry mechanisms.
+//
+// # Common methods
+//
+// Besides Next and Reset, the strategies share a set of methods that work
+// the same way for each of them, where the strategy supports them:
+//
+//   - NextContext is Next with a cancellation check, see ContextSequence.
+//   - Attempts and Elapsed report the progress since construction or Reset
+//     without advancing the strategy.
+//   - RemainingRetries reports the retries left under WithMaxRetries. Other
+//     limits, such as the elapsed budget, may end the sequence earlier.
+//   - Remaining reports the elapsed budget left under WithMaxElapsed.
+//   - Derive returns a copy that starts over, but is charged with the
+//     elapsed time so far, e.g. for a nested operation. The budget is a
+//     snapshot: time spent by either one afterwards does not affect the
+//     other. With WithStartTime, both measure the elapsed time from the
+//     same start time. ChannelSequence cannot be copied and has no Derive.
+//   - Clone returns an independent copy including the current state, e.g.
+//     for a parallel retry branch. The copy continues the random sequence
+//     on a generator of its own if the source is a *rand.PCG, as by
+//     default, or a *rand.ChaCha8. Other sources, a SharedBudget, a
+//     SharedLimiter and stateful jitters stay shared. ChannelSequence has
+//     no Clone.
+//   - Iter ranges over the next delays, see Iterate. The strategy is left
+//     where the loop stopped.
+//   - IterSchedule is like Iter, but also yields the time at which each
+//     delay ends. Both advance the strategy like a retry loop would,
+//     without sleeping.
+//   - CumulativeDelay sums the first delays without jitter on a copy,
+//     leaving the strategy untouched. Only the strategies with a
+//     predictable schedule have it.
+//   - JitterSpread returns the standard deviation of the next delay,
+//     sampled n times on independent copies. The strategy, including its
+//     random number generator, is left untouched. A value near zero means
+//     jitter has no effect, e.g. with NoneJitter or delays too small for
+//     it to make a difference.
+//   - DistinctDelays returns the number of unique values among the next n
+//     delays, computed on an independent copy. A result of 1 for a
+//     jittered strategy means the jitter is silently a no-op, e.g. because
+//     the delays are too small for it to make a difference. ChannelSequence
+//     has neither JitterSpread nor DistinctDelays.
+//   - WithOverrides builds a new strategy from the same parameters and
+//     options plus more options. It draws from the same random source
+//     unless another WithRandSource is passed. ChannelSequence has no
+//     WithOverrides.
 package backoff
 
 import (
+	"context"
+	"iter"
 	"math"
 	"math/rand/v2"
 	"time"
@@ -21,14 +68,307 @@ type Sequence interface {
 	Reset()
 }
 
+// ContextSequence is a Sequence that can also check a context atomically
+// with advancing. All strategies of this package implement it.
+type ContextSequence interface {
+	Sequence
+
+	// NextContext is like Next, but returns (0, false) without advancing
+	// the sequence if ctx is already done.
+	NextContext(ctx context.Context) (time.Duration, bool)
+}
+
+// nextContext advances s unless ctx is already done.
+func nextContext(ctx context.Context, s Sequence) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+	return s.Next()
+}
+
+// defaultMaxInterval caps the delays of Decorrelated, and of any strategy
+// retrying forever with WithSaneDefaults, if no maxInterval is configured.
+const defaultMaxInterval = 30 * time.Second
+
 // options holds configuration for backoff strategies.
 type options struct {
-	maxRetries  int           // -1 = infinite retries
-	maxElapsed  time.Duration // 0 = no time limit
-	rand        *rand.Rand    // random number generator for jitter
-	maxInterval time.Duration // maximum delay interval
-	minInterval time.Duration // minimum delay interval
-	jitter      Jitter        // jitter strategy to apply
+	maxRetries       int                             // -1 = infinite retries
+	maxElapsed       time.Duration                   // 0 = no time limit
+	elapsedJitter    float64                         // fraction by which maxElapsed is randomized
+	rand             *rand.Rand                      // random number generator for jitter
+	src              rand.Source                     // source of rand, copied by Clone
+	randSet          bool                            // rand was configured by an option
+	deadline         time.Time                       // context deadline, resolved by applyOptions
+	expired          bool                            // deadline had passed at construction
+	maxInterval      time.Duration                   // maximum delay interval
+	dynamicMax       func(attempt int) time.Duration // per-attempt maxInterval, overrides maxInterval
+	minInterval      time.Duration                   // minimum delay interval
+	jitter           Jitter                          // jitter strategy to apply
+	fixedJitter      *fixedJitter                    // non-nil = replaces jitter, for tests
+	clock            Clock                           // time source for wall-clock elapsed tracking
+	start            time.Time                       // zero = elapsed is the sum of returned delays
+	spentMark        time.Duration                   // highest wall-clock elapsed seen so far
+	elapsedOffset    time.Duration                   // elapsed time charged at start and on Reset
+	fillBudget       bool                            // truncate the final delay to the remaining budget
+	evenDistribution bool                            // scale Exponential delays to fill the budget
+	shared           *SharedBudget                   // nil = no shared retry budget
+	limiter          *SharedLimiter                  // nil = no shared rate limit
+	flatCap          bool                            // Decorrelated stays at the cap once reached
+	additiveBase     time.Duration                   // constant added to every Exponential delay
+	deadlineFactor   float64                         // growth of Deadline delays, 0 = even
+	randomStart      int                             // max steps skipped at construction
+	skipped          int                             // steps skipped at construction, 0 after Reset
+	resetHook        func()                          // called at the end of Reset
+	resetCooldown    time.Duration                   // minimum time between effective resets
+	freeFirst        bool                            // first delay is not charged to elapsed
+	resolution       time.Duration                   // 0 = delays are not truncated
+	tickPeriod       time.Duration                   // 0 = delays are not aligned
+	tickOffset       time.Duration                   // in [0, tickPeriod)
+	firstMin         time.Duration                   // lower bound of the first delay
+	firstMax         time.Duration                   // upper bound of the first delay
+	hasFirstRange    bool                            // whether the first delay is clamped
+	lastReset        time.Time                       // time of the last effective reset
+	saneDefaults     bool                            // cap maxInterval when retrying forever
+	fallback         time.Duration                   // ChannelSequence delay if none is ready
+	hasFallback      bool                            // ChannelSequence does not block
+	scale            func() float64                  // nil = no runtime scaling
+	health           func() float64                  // nil = no health interpolation
+	opts             []Option                        // options the strategy was created with
+}
+
+// with returns the options the strategy was created with followed by extra.
+// The result never aliases the stored slice.
+func (o *options) with(extra []Option) []Option {
+	opts := make([]Option, 0, len(o.opts)+len(extra))
+	opts = append(opts, o.opts...)
+	return append(opts, extra...)
+}
+
+// spent returns the elapsed time to compare against maxElapsed. If a start
+// time is configured, this is the wall-clock time since start; otherwise the
+// simulated elapsed time (the sum of delays returned so far) is used.
+//
+// Wall-clock elapsed time never decreases: if the clock reports a time
+// earlier than a previous reading, the highest elapsed time seen is kept.
+func (o *options) spent(elapsed time.Duration) time.Duration {
+	if o.start.IsZero() {
+		return elapsed
+	}
+
+	d := o.clock.Now().Sub(o.start)
+	if d < o.spentMark {
+		return o.spentMark
+	}
+	o.spentMark = d
+	return d
+}
+
+// startRand returns the generator for the draws made at construction by
+// WithRandomStart and WithElapsedJitter. Without WithRandSource or
+// WithFastRand it is randomly seeded, since the fixed-seed default would
+// give every instance the same start.
+func (o *options) startRand() *rand.Rand {
+	if o.randSet {
+		return o.rand
+	}
+	return rand.New(globalSource{})
+}
+
+// startSkip returns the number of steps to skip at construction, drawn
+// from [0, o.randomStart] as configured by WithRandomStart and capped at
+// maxRetries. The strategies advance only their growth state by that many
+// steps, so that jitter, bounds, callbacks and budgets are not involved.
+func (o *options) startSkip() int {
+	if o.randomStart <= 0 {
+		return 0
+	}
+	n, _ := randInt64N(o.startRand(), int64(o.randomStart)+1)
+	if o.maxRetries >= 0 {
+		n = min(n, int64(o.maxRetries))
+	}
+	o.skipped = int(n)
+	return o.skipped
+}
+
+// acquire consumes one retry from the shared budget, if configured.
+// Returns false if the shared budget is used up.
+func (o *options) acquire() bool {
+	return o.shared == nil || o.shared.take()
+}
+
+// free reports whether the delay at the given retry count is exempt from
+// the elapsed budget, as configured by WithFreeFirstAttempt. The first
+// delay is the first one returned, after the steps skipped at construction.
+func (o *options) free(retries int) bool {
+	return o.freeFirst && retries == o.skipped
+}
+
+// charge returns the elapsed time charged for delay d at the given retry
+// count: d, or 0 if the delay is free.
+func (o *options) charge(retries int, d time.Duration) time.Duration {
+	if o.free(retries) {
+		return 0
+	}
+	return d
+}
+
+// applyJitter applies the jitter strategy to d. If it implements
+// ElapsedAwareJitter, it also receives the elapsed time charged so far.
+func (o *options) applyJitter(d, elapsed time.Duration) time.Duration {
+	if j, ok := o.jitter.(ElapsedAwareJitter); ok {
+		return j.ApplyWithElapsed(d, o.spent(elapsed), o.rand)
+	}
+	return o.jitter.Apply(d, o.rand)
+}
+
+// finish applies the last steps of Next to d: alignment to the grid set
+// with WithTickAlignment, truncation to the resolution set with
+// WithMillisecondResolution, then the extension to the next slot of the
+// shared limiter. An extended delay is aligned again and rounded up
+// instead, so that it never fires before its slot.
+func (o *options) finish(d time.Duration) time.Duration {
+	d = o.align(d)
+	r := o.resolution
+	if r > 0 && d > 0 {
+		d = max(d.Truncate(r), r)
+	}
+	if o.limiter == nil {
+		return d
+	}
+
+	t := o.limiter.reserve(d)
+	if t > d {
+		t = o.align(t)
+		if r > 0 && t%r != 0 {
+			t = addDuration(t.Truncate(r), r)
+		}
+	}
+	return t
+}
+
+// align rounds d up to the next point of the grid set with
+// WithTickAlignment, i.e. the next multiple of the period plus the offset.
+func (o *options) align(d time.Duration) time.Duration {
+	p := o.tickPeriod
+	if p <= 0 {
+		return d
+	}
+	if d <= o.tickOffset {
+		return o.tickOffset
+	}
+	if rem := (d - o.tickOffset) % p; rem != 0 {
+		return addDuration(d, p-rem)
+	}
+	return d
+}
+
+// firstRange clamps the delay of the first attempt to the range set with
+// WithFirstDelayRange. Later attempts are returned unchanged. As for free,
+// the first attempt follows the steps skipped at construction.
+func (o *options) firstRange(d time.Duration, attempt int) time.Duration {
+	if !o.hasFirstRange || attempt != o.skipped {
+		return d
+	}
+	return applyBounds(d, o.firstMin, o.firstMax)
+}
+
+// maxAt returns the maximum delay for the given attempt (0-based): the
+// result of the WithDynamicMaxInterval function if set, maxInterval
+// otherwise.
+func (o *options) maxAt(attempt int) time.Duration {
+	if o.dynamicMax != nil {
+		return o.dynamicMax(attempt)
+	}
+	return o.maxInterval
+}
+
+// outOfRetries reports whether no retry is left after the given number of
+// retries, either under maxRetries or because the context deadline had
+// already passed at construction.
+func (o *options) outOfRetries(retries int) bool {
+	return o.expired || o.maxRetries >= 0 && retries >= o.maxRetries
+}
+
+// remainingRetries returns the retries left under maxRetries after the
+// given number of retries, or -1 if retries are unlimited.
+func remainingRetries(o *options, retries int) int {
+	if o.expired {
+		return 0
+	}
+	if o.maxRetries < 0 {
+		return -1
+	}
+	return max(o.maxRetries-retries, 0)
+}
+
+// allowReset reports whether a call to Reset takes effect, recording its
+// time if so. With WithResetCooldown, resets within the cooldown of the
+// last effective one are ignored.
+func (o *options) allowReset() bool {
+	if o.resetCooldown <= 0 {
+		return true
+	}
+
+	now := o.clock.Now()
+	if !o.lastReset.IsZero() && now.Sub(o.lastReset) < o.resetCooldown {
+		return false
+	}
+	o.lastReset = now
+	return true
+}
+
+// reset forgets the steps skipped at construction, since Reset rewinds to
+// the very beginning, and runs the hook registered with WithResetHook, if
+// any. It is called by every strategy at the end of Reset.
+func (o *options) reset() {
+	o.skipped = 0
+	if o.resetHook != nil {
+		o.resetHook()
+	}
+}
+
+// scaled applies the runtime modulators to d, if configured: the health
+// score interpolation, then the runtime scale. The scale is clamped to
+// [minScale, maxScale] and the result is capped at math.MaxInt64.
+func (o *options) scaled(d time.Duration) time.Duration {
+	d = o.healthy(d)
+	if o.scale == nil {
+		return d
+	}
+
+	f := o.scale()
+	switch {
+	case math.IsNaN(f):
+		return d
+	case f < minScale:
+		f = minScale
+	case f > maxScale:
+		f = maxScale
+	}
+
+	v := float64(d) * f
+	if v >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(v)
+}
+
+// healthy interpolates between minInterval and d by the current health
+// score set with WithHealthScore: the healthier, the closer to
+// minInterval. The score is clamped to [0, 1]; NaN leaves d unchanged.
+func (o *options) healthy(d time.Duration) time.Duration {
+	if o.health == nil {
+		return d
+	}
+
+	score := o.health()
+	if math.IsNaN(score) {
+		return d
+	}
+	score = min(max(score, 0), 1)
+
+	low := min(o.minInterval, d)
+	return low + time.Duration(float64(d-low)*(1-score))
 }
 
 // Constant implements a constant backoff strategy with fixed delay intervals.
@@ -43,6 +383,8 @@ type Constant struct {
 	interval time.Duration // fixed delay interval
 	retries  int           // current retry count
 	elapsed  time.Duration // total elapsed time
+	last     time.Duration // delay returned by the last call to Next
+	hasLast  bool          // whether last holds a valid delay
 }
 
 // NewConstant creates a new constant backoff strategy with the specified interval.
@@ -59,10 +401,16 @@ type Constant struct {
 //	// 1 second delay with 30 second total timeout
 //	constant := NewConstant(time.Second, WithMaxElapsed(30*time.Second))
 func NewConstant(d time.Duration, opts ...Option) *Constant {
-	return &Constant{
+	o := applyOptions(opts)
+
+	c := &Constant{
 		interval: d,
-		options:  applyOptions(opts),
+		options:  o,
+		elapsed:  o.elapsedOffset,
 	}
+	c.skip(o.startSkip())
+
+	return c
 }
 
 // Next returns the next delay duration and whether more retries are allowed.
@@ -73,25 +421,196 @@ func NewConstant(d time.Duration, opts ...Option) *Constant {
 //   - time.Duration: The delay duration (always the configured interval)
 //   - bool: true if more retries are allowed, false if limits are reached
 func (c *Constant) Next() (time.Duration, bool) {
-	if c.options.maxRetries >= 0 && c.retries >= c.options.maxRetries {
-		return 0, false
+	d, _, ok := c.NextDetailed()
+	return d, ok
+}
+
+// NextContext is like Next, but returns (0, false) without counting a
+// retry if ctx is done.
+func (c *Constant) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, c)
+}
+
+// NextDetailed is like Next, but also returns the raw delay, the
+// configured interval before jitter, bounds and scaling are applied.
+// Comparing it with the result shows why a delay was clamped or jittered
+// to a surprising value.
+//
+// Returns (0, 0, false) if limits are reached.
+func (c *Constant) NextDetailed() (result time.Duration, raw time.Duration, ok bool) {
+	if c.options.outOfRetries(c.retries) {
+		c.last, c.hasLast = 0, false
+		return 0, 0, false
 	}
 
-	if c.options.maxElapsed > 0 && c.elapsed >= c.options.maxElapsed {
-		return 0, false
+	if c.options.maxElapsed > 0 && !c.options.free(c.retries) && c.options.spent(c.elapsed) >= c.options.maxElapsed {
+		c.last, c.hasLast = 0, false
+		return 0, 0, false
 	}
 
+	if !c.options.acquire() {
+		c.last, c.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	d := c.options.scaled(c.interval)
+	d = c.options.finish(d)
+	c.elapsed += c.options.charge(c.retries, d)
 	c.retries++
-	c.elapsed += c.interval
-	return c.interval, true
+	c.last, c.hasLast = d, true
+	return d, c.interval, true
 }
 
 // Reset resets the constant backoff to its initial state.
-// This clears the retry count and elapsed time, allowing the sequence
-// to be reused for a new set of retry attempts.
+// This clears the retry count and the delay reported by Current, and
+// restores the elapsed time to its initial offset (see WithElapsedOffset),
+// allowing the sequence to be reused for a new set of retry attempts.
 func (c *Constant) Reset() {
+	if !c.options.allowReset() {
+		return
+	}
 	c.retries = 0
-	c.elapsed = 0
+	c.elapsed = c.options.elapsedOffset
+	c.last = 0
+	c.hasLast = false
+	c.options.reset()
+}
+
+// SoftReset halves the retry count, rounded down, and clears the delay
+// reported by Current. Unlike Reset, the elapsed time is kept, so the max
+// elapsed limit still accounts for the time already spent.
+//
+// This models "things improved a bit": after a transient blip the sequence
+// gets some of its retries back without forgetting that it has been
+// struggling for a while.
+func (c *Constant) SoftReset() {
+	c.retries /= 2
+	c.last = 0
+	c.hasLast = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence. This allows deriving several values (e.g. a
+// timeout and a sleep) from the same attempt.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (c *Constant) Current() (time.Duration, bool) {
+	return c.last, c.hasLast
+}
+
+// Attempts returns the number of intervals returned since construction or
+// Reset, less those rewound by SoftReset.
+func (c *Constant) Attempts() int {
+	return c.retries
+}
+
+// Elapsed returns the sum of the intervals returned so far, plus the
+// offset set with WithElapsedOffset.
+func (c *Constant) Elapsed() time.Duration {
+	return c.elapsed
+}
+
+// RemainingRetries returns the retries c has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (c *Constant) RemainingRetries() int {
+	return remainingRetries(c.options, c.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (c *Constant) budget() (limit, spent time.Duration) {
+	return c.options.maxElapsed, c.options.spent(c.elapsed)
+}
+
+// Remaining returns the elapsed budget c has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (c *Constant) Remaining() time.Duration {
+	return remaining(c)
+}
+
+// Derive returns a constant backoff with the same interval and options
+// that is charged with the elapsed time of c, so that a nested operation
+// stops at the same deadline.
+func (c *Constant) Derive() *Constant {
+	return derive(c, c)
+}
+
+// Clone returns an independent copy of c, including its retry count and
+// elapsed time.
+func (c *Constant) Clone() *Constant {
+	return clone(c)
+}
+
+// Iter returns an iterator over the next intervals of c, see Iterate.
+func (c *Constant) Iter() iter.Seq[time.Duration] {
+	return Iterate(c)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each
+// interval of c ends, counted from now or, if now is zero, from the time
+// of the Clock set with WithClock. It stops early when ctx is done.
+func (c *Constant) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, c, c.options, now)
+}
+
+// Describe returns the configuration of the constant backoff.
+func (c *Constant) Describe() Description {
+	return c.options.describe(Description{
+		Kind: KindConstant,
+		Base: c.interval,
+	})
+}
+
+// skip advances the retry count by n steps, as WithRandomStart does at
+// construction.
+func (c *Constant) skip(n int) {
+	c.retries += n
+}
+
+// DelayAt returns the delay the strategy would produce for the given
+// attempt without modifying its state. For constant backoff this is always
+// the configured interval, ignoring runtime scaling and elapsed limits.
+func (c *Constant) DelayAt(attempt int) time.Duration {
+	return c.interval
+}
+
+// JitterSpread returns the standard deviation of the next interval of c,
+// which only jitter varies; see Common methods.
+func (c *Constant) JitterSpread(n int) time.Duration {
+	return jitterSpread(c, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// intervals of c, which is 1 unless jitter varies them; see Common
+// methods.
+func (c *Constant) DistinctDelays(n int) int {
+	return distinctDelays(c, n)
+}
+
+// CumulativeDelay returns the sum of the first attempts intervals,
+// ignoring the configured limits, e.g. to check how long attempts retries
+// might block.
+func (c *Constant) CumulativeDelay(attempts int) time.Duration {
+	return cumulativeDelay(c, attempts)
+}
+
+// fork returns a copy of the constant backoff including its state.
+func (c *Constant) fork(mod func(*options)) Sequence {
+	f := *c
+	f.options = forkOptions(c.options, mod)
+	return &f
+}
+
+// WithOverrides returns a new constant backoff with the same interval and
+// options, plus opts applied on top, starting from its initial state.
+//
+// Example:
+//
+//	relaxed := c.WithOverrides(WithMaxRetries(10))
+func (c *Constant) WithOverrides(opts ...Option) Sequence {
+	return NewConstant(c.interval, c.options.with(opts)...)
 }
 
 // Exponential implements an exponential backoff strategy where delays
@@ -103,10 +622,13 @@ type Exponential struct {
 	options *options
 	base    time.Duration // initial delay duration
 	factor  float64       // multiplier for each retry
+	first   time.Duration // delay of the first retry, base unless distributed
 
 	retries int           // current retry count
 	elapsed time.Duration // total elapsed time
-	current time.Duration // current calculated delay
+	current time.Duration // current calculated delay, before bounds
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
 }
 
 // NewExponential creates a new exponential backoff strategy.
@@ -133,11 +655,21 @@ func NewExponential(base time.Duration, factor float64, opts ...Option) *Exponen
 		factor = 2.0
 	}
 
-	return &Exponential{
-		options: applyOptions(opts),
+	o := applyOptions(opts)
+
+	e := &Exponential{
+		options: o,
 		base:    base,
 		factor:  factor,
+		first:   base,
+		elapsed: o.elapsedOffset,
+	}
+	if o.evenDistribution && o.maxElapsed > 0 && o.maxRetries > 0 {
+		e.first = e.distribute()
 	}
+	e.skip(o.startSkip())
+
+	return e
 }
 
 // Next returns the next exponentially increased delay duration.
@@ -145,45 +677,304 @@ func NewExponential(base time.Duration, factor float64, opts ...Option) *Exponen
 //
 // The calculated delay is subject to:
 //   - Jitter application (if configured)
-//   - Min/max interval bounds
+//   - A constant additive offset (if WithAdditiveBase is set)
+//   - Min/max interval bounds, which apply to the returned delay only and
+//     do not distort the growth of subsequent delays
 //   - Overflow protection (capped at math.MaxInt64)
+//   - Runtime scaling (if configured), which does not affect growth
+//   - Truncation to the remaining elapsed budget (if WithFillBudget is set)
 //
 // Returns:
 //   - time.Duration: The calculated delay duration
 //   - bool: true if more retries are allowed, false if limits are reached
 func (e *Exponential) Next() (time.Duration, bool) {
-	if e.options.maxRetries >= 0 && e.retries >= e.options.maxRetries {
-		return 0, false
+	d, _, ok := e.NextDetailed()
+	return d, ok
+}
+
+// NextContext is like Next, but returns (0, false) without growing the
+// delay if ctx is done.
+func (e *Exponential) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, e)
+}
+
+// NextDetailed is like Next, but also returns the raw delay, the geometric
+// value before jitter, bounds and scaling are applied. Comparing it with
+// the result shows why a delay was clamped or jittered to a surprising
+// value.
+//
+// Returns (0, 0, false) if limits are reached.
+func (e *Exponential) NextDetailed() (result time.Duration, raw time.Duration, ok bool) {
+	if e.options.outOfRetries(e.retries) {
+		e.last, e.hasLast = 0, false
+		return 0, 0, false
 	}
 
-	d := e.base
+	raw = e.first
 	if e.retries > 0 {
-		d = e.current * time.Duration(e.factor)
+		raw = e.grow(e.current)
 	}
 
-	d = e.options.jitter.Apply(d, e.options.rand)
+	d := e.options.applyJitter(raw, e.elapsed)
 
-	if float64(d) > float64(math.MaxInt64) {
-		d = time.Duration(math.MaxInt64)
+	// The progression continues from the un-bounded delay, so that
+	// min/max bounds only affect the returned value and not the growth.
+	next := d
+	d = addDuration(d, e.options.additiveBase)
+	d = applyBounds(d, e.options.minInterval, e.options.maxAt(e.retries))
+	d = e.options.firstRange(d, e.retries)
+	delay := e.options.scaled(d)
+	if e.options.maxElapsed > 0 && !e.options.free(e.retries) {
+		remaining := e.options.maxElapsed - e.options.spent(e.elapsed)
+		if delay >= remaining {
+			if !e.options.fillBudget || remaining <= 0 {
+				e.last, e.hasLast = 0, false
+				return 0, 0, false
+			}
+			delay = remaining
+		}
 	}
 
-	d = applyBounds(d, e.options.minInterval, e.options.maxInterval)
-	if e.options.maxElapsed > 0 && e.elapsed+d >= e.options.maxElapsed {
-		return 0, false
+	if !e.options.acquire() {
+		e.last, e.hasLast = 0, false
+		return 0, 0, false
 	}
 
-	e.current = d
+	delay = e.options.finish(delay)
+	e.current = next
+	e.elapsed += e.options.charge(e.retries, delay)
 	e.retries++
-	e.elapsed += d
-	return d, true
+	e.last, e.hasLast = delay, true
+	return delay, raw, true
+}
+
+// skip advances the growth state by n steps, as WithRandomStart does at
+// construction. The growth continues from the un-jittered delays.
+func (e *Exponential) skip(n int) {
+	for ; n > 0; n-- {
+		if e.retries > 0 {
+			e.current = e.grow(e.current)
+		} else {
+			e.current = e.first
+		}
+		e.retries++
+	}
+}
+
+// DelayAt returns the delay the strategy would produce for the given
+// attempt (0-based) without jitter, without modifying its state. The
+// delay is subject to min/max bounds and overflow protection, but
+// ignores runtime scaling and elapsed limits.
+//
+// This is useful for documentation, dashboards, and tests.
+func (e *Exponential) DelayAt(attempt int) time.Duration {
+	d := e.first
+	for i := 0; i < attempt; i++ {
+		next := e.grow(d)
+		if next == d {
+			break // reached a fixed point, e.g. math.MaxInt64
+		}
+		d = next
+	}
+	d = addDuration(d, e.options.additiveBase)
+	d = applyBounds(d, e.options.minInterval, e.options.maxAt(attempt))
+	return e.options.firstRange(d, attempt)
+}
+
+// JitterSpread returns the standard deviation of the next delay of e; see
+// Common methods.
+func (e *Exponential) JitterSpread(n int) time.Duration {
+	return jitterSpread(e, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of e; see Common methods.
+func (e *Exponential) DistinctDelays(n int) int {
+	return distinctDelays(e, n)
+}
+
+// CumulativeDelay returns the sum of the first attempts delays without
+// jitter, ignoring the configured limits. Real runs with jitter vary
+// around this value.
+func (e *Exponential) CumulativeDelay(attempts int) time.Duration {
+	return cumulativeDelay(e, attempts)
+}
+
+// fork returns a copy of the exponential backoff including its state.
+func (e *Exponential) fork(mod func(*options)) Sequence {
+	f := *e
+	f.options = forkOptions(e.options, mod)
+	return &f
+}
+
+// grow returns the delay following d, capped at math.MaxInt64.
+func (e *Exponential) grow(d time.Duration) time.Duration {
+	return mulDuration(d, e.factor)
+}
+
+// distribute returns the first delay for which the un-jittered delays of
+// maxRetries attempts add up to just under the remaining elapsed budget.
+func (e *Exponential) distribute() time.Duration {
+	budget := e.options.maxElapsed - e.options.elapsedOffset - 1
+	if budget <= 0 || e.base <= 0 {
+		return e.base
+	}
+
+	var sum float64
+	d := e.base
+	for i := 0; i < e.options.maxRetries; i++ {
+		next := e.grow(d)
+		if next == d {
+			// reached a fixed point, the remaining delays are all d
+			sum += float64(d) * float64(e.options.maxRetries-i)
+			break
+		}
+		sum += float64(d)
+		d = next
+	}
+	return time.Duration(float64(e.base) * float64(budget) / sum)
+}
+
+// shrink returns the delay preceding d. It is the inverse of grow, except
+// for delays that grow saturated at math.MaxInt64.
+func (e *Exponential) shrink(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / e.factor)
 }
 
 // Reset resets the exponential backoff to its initial state.
-// This clears the retry count, elapsed time, and current delay calculation.
+// This clears the retry count, current delay calculation and the delay
+// reported by Current, and restores the elapsed time to its initial offset.
 func (e *Exponential) Reset() {
+	if !e.options.allowReset() {
+		return
+	}
 	e.retries = 0
-	e.elapsed = 0
+	e.elapsed = e.options.elapsedOffset
 	e.current = 0
+	e.last = 0
+	e.hasLast = false
+	e.options.reset()
+}
+
+// SoftReset rewinds the sequence by half of its retries, rounded up: the
+// retry count is halved and the current delay is divided by the factor
+// once for every rewound retry, so the next delay is the one that followed
+// the kept retries. The delay reported by Current is cleared. Unlike Reset,
+// the elapsed time is kept.
+//
+// Because growth continues from the jittered delay, the rewound delay is
+// only approximate when jitter is configured.
+//
+// Example:
+//
+//	// 100ms, 200ms, 400ms, 800ms, then after SoftReset: 400ms, 800ms, ...
+//	e.SoftReset()
+func (e *Exponential) SoftReset() {
+	keep := e.retries / 2
+	for i := keep; i < e.retries; i++ {
+		e.current = e.shrink(e.current)
+	}
+	if keep == 0 {
+		e.current = 0
+	}
+	e.retries = keep
+	e.last = 0
+	e.hasLast = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (e *Exponential) Current() (time.Duration, bool) {
+	return e.last, e.hasLast
+}
+
+// Attempts returns the number of delays returned since construction or
+// Reset, less those rewound by SoftReset.
+func (e *Exponential) Attempts() int {
+	return e.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (e *Exponential) Elapsed() time.Duration {
+	return e.elapsed
+}
+
+// RemainingRetries returns the retries e has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (e *Exponential) RemainingRetries() int {
+	return remainingRetries(e.options, e.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (e *Exponential) budget() (limit, spent time.Duration) {
+	return e.options.maxElapsed, e.options.spent(e.elapsed)
+}
+
+// Remaining returns the elapsed budget e has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (e *Exponential) Remaining() time.Duration {
+	return remaining(e)
+}
+
+// Derive returns an exponential backoff that starts over from the base
+// delay, but is charged with the elapsed time of e, so that a nested
+// operation stops at the same deadline.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, parent, func(ctx context.Context) error {
+//		if err := fetch(ctx); err != nil {
+//			return err
+//		}
+//		return RetryWithContext(ctx, parent.Derive(), store)
+//	})
+func (e *Exponential) Derive() *Exponential {
+	return derive(e, e)
+}
+
+// Clone returns an independent copy of e, including the current delay,
+// e.g. for a parallel retry branch.
+func (e *Exponential) Clone() *Exponential {
+	return clone(e)
+}
+
+// Iter returns an iterator over the next delays of e, see Iterate.
+func (e *Exponential) Iter() iter.Seq[time.Duration] {
+	return Iterate(e)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of e ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (e *Exponential) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, e, e.options, now)
+}
+
+// Describe returns the configuration of the exponential backoff. Base is
+// the configured base, even if WithEvenDistribution scales the delays.
+func (e *Exponential) Describe() Description {
+	return e.options.describe(Description{
+		Kind:   KindExponential,
+		Base:   e.base,
+		Factor: e.factor,
+	})
+}
+
+// WithOverrides returns a new exponential backoff with the same base,
+// factor and options, plus opts applied on top, starting from its initial
+// state.
+//
+// Example:
+//
+//	capped := e.WithOverrides(WithMaxInterval(time.Minute))
+func (e *Exponential) WithOverrides(opts ...Option) Sequence {
+	return NewExponential(e.base, e.factor, e.options.with(opts)...)
 }
 
 // Decorrelated implements a decorrelated jitter backoff strategy.
@@ -191,7 +982,8 @@ func (e *Exponential) Reset() {
 // across multiple clients, effectively preventing thundering herd problems.
 //
 // The algorithm picks a random delay between the minimum interval and
-// (previous_delay * factor), providing both exponential growth characteristics
+// (previous_delay * factor), starting from a random delay up to the initial
+// duration, providing both exponential growth characteristics
 // and randomization to spread out retry attempts.
 type Decorrelated struct {
 	initial time.Duration // initial delay duration
@@ -201,6 +993,9 @@ type Decorrelated struct {
 	retries int           // current retry count
 	elapsed time.Duration // total elapsed time
 	prev    time.Duration // previous delay duration
+	capped  bool          // whether the growth reached maxInterval
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
 }
 
 // NewDecorrelated creates a new decorrelated jitter backoff strategy.
@@ -232,64 +1027,282 @@ func NewDecorrelated(initial time.Duration, factor float64, opts ...Option) *Dec
 	o := applyOptions(opts)
 
 	if o.maxInterval <= 0 {
-		o.maxInterval = 30 * time.Second
+		o.maxInterval = defaultMaxInterval
 	}
 
-	return &Decorrelated{
+	dcr := &Decorrelated{
 		initial: initial,
 		factor:  factor,
 		options: o,
+		elapsed: o.elapsedOffset,
 	}
+	dcr.skip(o.startSkip())
+
+	return dcr
 }
 
 // Next returns the next decorrelated delay duration.
-// For the first retry, returns the initial duration.
+// For the first retry, picks a random duration between minInterval and
+// the initial duration, so that clients starting at the same time do not
+// all fire their first retry at once.
 // For subsequent retries, picks a random duration between minInterval
 // and (previous_delay * factor), bounded by maxInterval.
 //
 // This randomization helps prevent multiple clients from retrying
 // simultaneously, reducing load spikes on recovering systems.
 //
+// With WithFlatCap, once (previous_delay * factor) has reached maxInterval,
+// every following delay is picked between minInterval and maxInterval.
+//
 // Returns:
 //   - time.Duration: The calculated random delay duration
 //   - bool: true if more retries are allowed, false if limits are reached
 func (dcr *Decorrelated) Next() (time.Duration, bool) {
-	if dcr.options.maxRetries >= 0 && dcr.retries >= dcr.options.maxRetries {
-		return 0, false
+	d, _, ok := dcr.NextDetailed()
+	return d, ok
+}
+
+// NextContext is like Next, but returns (0, false) without drawing a delay
+// if ctx is done.
+func (dcr *Decorrelated) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, dcr)
+}
+
+// NextDetailed is like Next, but also returns the raw delay, the randomly
+// chosen base before jitter, bounds and scaling are applied. Comparing it
+// with the result shows why a delay was clamped or jittered to a
+// surprising value.
+//
+// Returns (0, 0, false) if limits are reached.
+func (dcr *Decorrelated) NextDetailed() (result time.Duration, raw time.Duration, ok bool) {
+	if dcr.options.outOfRetries(dcr.retries) {
+		dcr.last, dcr.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	maxInterval := dcr.options.maxAt(dcr.retries)
+	base, capped := dcr.draw(maxInterval)
+	delay := dcr.options.applyJitter(base, dcr.elapsed)
+	delay = applyBounds(delay, 0, maxInterval) // jitter must not exceed the cap
+	delay = dcr.options.firstRange(delay, dcr.retries)
+	delay = dcr.options.scaled(delay)
+
+	if dcr.options.maxElapsed > 0 && !dcr.options.free(dcr.retries) && dcr.options.spent(dcr.elapsed)+delay > dcr.options.maxElapsed {
+		dcr.last, dcr.hasLast = 0, false
+		return 0, 0, false
 	}
 
-	var base time.Duration
-	if dcr.retries == 0 || dcr.prev <= 0 {
-		base = dcr.initial
-	} else {
+	if !dcr.options.acquire() {
+		dcr.last, dcr.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	delay = dcr.options.finish(delay)
+	dcr.elapsed += dcr.options.charge(dcr.retries, delay)
+	dcr.retries++
+	dcr.prev = base
+	dcr.capped = capped
+	dcr.last, dcr.hasLast = delay, true
+	return delay, base, true
+}
+
+// draw picks the random base of the next retry, bounded by maxInterval,
+// and reports whether the growth has reached maxInterval.
+func (dcr *Decorrelated) draw(maxInterval time.Duration) (base time.Duration, capped bool) {
+	capped = dcr.capped
+	switch {
+	case capped && dcr.options.flatCap:
+		base = randBetween(dcr.options.rand, dcr.options.minInterval, maxInterval)
+	case dcr.retries == 0 || dcr.prev <= 0:
+		base = randBetween(dcr.options.rand, dcr.options.minInterval, dcr.initial)
+	default:
 		low := dcr.options.minInterval
-		high := time.Duration(float64(dcr.prev) * dcr.factor)
+		high := mulDuration(dcr.prev, dcr.factor)
 		high = max(high, low)
-		if high > dcr.options.maxInterval && dcr.options.maxInterval > 0 {
-			high = dcr.options.maxInterval
+		if high >= maxInterval && maxInterval > 0 {
+			high = maxInterval
+			capped = true
 		}
 		base = randBetween(dcr.options.rand, low, high)
 	}
+	return applyBounds(base, dcr.options.minInterval, maxInterval), capped
+}
 
-	base = applyBounds(base, dcr.options.minInterval, dcr.options.maxInterval)
-	delay := dcr.options.jitter.Apply(base, dcr.options.rand)
-
-	if dcr.options.maxElapsed > 0 && dcr.elapsed+delay > dcr.options.maxElapsed {
-		return 0, false
+// skip advances the growth state by n steps, as WithRandomStart does at
+// construction. The random bases are drawn as in Next, but without
+// jitter, bounds or limits.
+func (dcr *Decorrelated) skip(n int) {
+	for ; n > 0; n-- {
+		dcr.prev, dcr.capped = dcr.draw(dcr.options.maxAt(dcr.retries))
+		dcr.retries++
 	}
+}
 
-	dcr.retries++
-	dcr.elapsed += delay
-	dcr.prev = base
-	return delay, true
+// JitterSpread returns the standard deviation of the next delay of dcr,
+// which includes its own randomization besides jitter; see Common methods.
+func (dcr *Decorrelated) JitterSpread(n int) time.Duration {
+	return jitterSpread(dcr, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of dcr; see Common methods.
+func (dcr *Decorrelated) DistinctDelays(n int) int {
+	return distinctDelays(dcr, n)
 }
 
+// fork returns a copy of the decorrelated backoff including its state.
+func (dcr *Decorrelated) fork(mod func(*options)) Sequence {
+	f := *dcr
+	f.options = forkOptions(dcr.options, mod)
+	return &f
+}
+
+// Decorrelated has no DelayAt or CumulativeDelay methods: each delay
+// depends on the random choice made for the previous one, so there is no
+// deterministic delay for a given attempt.
+
 // Reset resets the decorrelated backoff to its initial state.
-// This clears the retry count, elapsed time, and previous delay history.
+// This clears the retry count, previous delay history, the cap state and
+// the delay reported by Current, and restores the elapsed time to its
+// initial offset.
 func (dcr *Decorrelated) Reset() {
+	if !dcr.options.allowReset() {
+		return
+	}
 	dcr.retries = 0
-	dcr.elapsed = 0
+	dcr.elapsed = dcr.options.elapsedOffset
 	dcr.prev = 0
+	dcr.capped = false
+	dcr.last = 0
+	dcr.hasLast = false
+	dcr.options.reset()
+}
+
+// SoftReset rewinds the sequence by half of its retries, rounded up: the
+// retry count is halved, the previous delay is divided by the factor once
+// for every rewound retry, and the cap state is cleared. The delay reported
+// by Current is cleared. Unlike Reset, the elapsed time is kept.
+func (dcr *Decorrelated) SoftReset() {
+	keep := dcr.retries / 2
+	for i := keep; i < dcr.retries; i++ {
+		dcr.prev = time.Duration(float64(dcr.prev) / dcr.factor)
+	}
+	if keep == 0 {
+		dcr.prev = 0
+	}
+	dcr.retries = keep
+	dcr.capped = false
+	dcr.last = 0
+	dcr.hasLast = false
+}
+
+// Decay multiplies the previous delay, which drives the growth of the next
+// one, by factor, e.g. after a success in a long-lived client that wants
+// to stay cautious. This is a middle ground between Reset, which zeroes
+// the growth baseline, and leaving it untouched, and enables AIMD-like
+// behavior. The retry count, elapsed time and the delay reported by
+// Current are kept.
+//
+// The decayed delay is clamped to at least the initial duration and
+// minInterval. A factor <= 0 drops it to that floor; a factor >= 1, or
+// NaN, leaves it unchanged. Decay has no effect before the first delay.
+//
+// Example:
+//
+//	if err := call(); err == nil {
+//		dcr.Decay(0.5) // halve the baseline, but keep backing off
+//	}
+func (dcr *Decorrelated) Decay(factor float64) {
+	if dcr.prev <= 0 || !(factor < 1) {
+		return
+	}
+
+	floor := max(dcr.initial, dcr.options.minInterval)
+	dcr.prev = max(time.Duration(float64(dcr.prev)*max(factor, 0)), floor)
+	dcr.capped = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence or drawing new random values.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (dcr *Decorrelated) Current() (time.Duration, bool) {
+	return dcr.last, dcr.hasLast
+}
+
+// Attempts returns the number of delays returned since construction or
+// Reset, less those rewound by SoftReset.
+func (dcr *Decorrelated) Attempts() int {
+	return dcr.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (dcr *Decorrelated) Elapsed() time.Duration {
+	return dcr.elapsed
+}
+
+// RemainingRetries returns the retries dcr has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (dcr *Decorrelated) RemainingRetries() int {
+	return remainingRetries(dcr.options, dcr.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (dcr *Decorrelated) budget() (limit, spent time.Duration) {
+	return dcr.options.maxElapsed, dcr.options.spent(dcr.elapsed)
+}
+
+// Remaining returns the elapsed budget dcr has left under WithMaxElapsed,
+// or math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (dcr *Decorrelated) Remaining() time.Duration {
+	return remaining(dcr)
+}
+
+// Derive returns a decorrelated backoff that starts over from a random
+// delay up to the initial one, but is charged with the elapsed time of
+// dcr, so that a nested operation stops at the same deadline.
+func (dcr *Decorrelated) Derive() *Decorrelated {
+	return derive(dcr, dcr)
+}
+
+// Clone returns an independent copy of dcr, including the previous delay.
+// With the default random source, the copy draws the same delays dcr
+// would.
+func (dcr *Decorrelated) Clone() *Decorrelated {
+	return clone(dcr)
+}
+
+// Iter returns an iterator over the next delays of dcr, see Iterate.
+func (dcr *Decorrelated) Iter() iter.Seq[time.Duration] {
+	return Iterate(dcr)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of dcr ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (dcr *Decorrelated) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, dcr, dcr.options, now)
+}
+
+// Describe returns the configuration of the decorrelated backoff, with
+// the default maxInterval filled in if none was configured.
+func (dcr *Decorrelated) Describe() Description {
+	return dcr.options.describe(Description{
+		Kind:   KindDecorrelated,
+		Base:   dcr.initial,
+		Factor: dcr.factor,
+	})
+}
+
+// WithOverrides returns a new decorrelated backoff with the same initial
+// delay, factor and options, plus opts applied on top, starting from its
+// initial state.
+func (dcr *Decorrelated) WithOverrides(opts ...Option) Sequence {
+	return NewDecorrelated(dcr.initial, dcr.factor, dcr.options.with(opts)...)
 }
 
 // applyBounds ensures the duration falls within the specified min/max bounds.
@@ -307,12 +1320,46 @@ func applyBounds(d, min, max time.Duration) time.Duration {
 	return d
 }
 
+// addDuration returns a + b, saturating at math.MaxInt64 and 0.
+func addDuration(a, b time.Duration) time.Duration {
+	if b > 0 && a > time.Duration(math.MaxInt64)-b {
+		return time.Duration(math.MaxInt64)
+	}
+	if a < 0 && b < 0 || a+b < 0 {
+		return 0
+	}
+	return a + b
+}
+
+// mulDuration returns d * f for a growth factor f > 1, clamped to
+// [d, math.MaxInt64].
+//
+// float64 has a 53-bit mantissa, so for d above 2^53ns (about 104 days)
+// float64(d) is already rounded, and the product is rounded once more.
+// Near math.MaxInt64 these roundings could yield a product that converts
+// to a value below d, i.e. a delay that shrinks, or one that does not fit
+// in an int64 at all. The cap check is done in float64 against 2^63, the
+// first value out of range, and the result is floored at d, so growth is
+// monotonic and hits the cap cleanly.
+func mulDuration(d time.Duration, f float64) time.Duration {
+	v := float64(d) * f
+	if v >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return max(time.Duration(v), d)
+}
+
 // randBetween generates a random duration between low and high (inclusive).
-// If high <= low, returns low. Used for decorrelated jitter calculations.
+// If high <= low, returns low. If the random number generator fails,
+// returns high. Used for decorrelated jitter calculations.
 func randBetween(r *rand.Rand, low, high time.Duration) time.Duration {
 	if high <= low {
 		return low
 	}
 	span := high - low
-	return low + time.Duration(r.Int64N(int64(span)+1))
+	v, ok := randInt64N(r, int64(span)+1)
+	if !ok {
+		return high
+	}
+	return low + time.Duration(v)
 }
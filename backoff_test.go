@@ -1,7 +1,11 @@
 package backoff
 
 import (
+	"context"
+	"math"
 	"math/rand/v2"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 )
@@ -222,6 +226,133 @@ func TestExponential(t *testing.T) {
 		}
 	})
 
+	t.Run("min interval does not distort growth", func(t *testing.T) {
+		e := NewExponential(time.Millisecond, 2.0, WithMinInterval(100*time.Millisecond))
+
+		// 1, 2, 4, ..., 64ms are raised to 100ms; growth continues from the
+		// un-bounded values rather than from 100ms
+		var got []time.Duration
+		for i := 0; i < 9; i++ {
+			d, _ := e.Next()
+			got = append(got, d)
+		}
+
+		for i := 0; i < 7; i++ {
+			if got[i] != 100*time.Millisecond {
+				t.Errorf("Call %d: expected 100ms, got %v", i+1, got[i])
+			}
+		}
+		if got[7] != 128*time.Millisecond || got[8] != 256*time.Millisecond {
+			t.Errorf("Expected 128ms and 256ms after the minimum, got %v and %v", got[7], got[8])
+		}
+	})
+
+	t.Run("with additive base", func(t *testing.T) {
+		e := NewExponential(10*time.Millisecond, 2.0,
+			WithAdditiveBase(time.Second),
+			WithMaxInterval(1100*time.Millisecond))
+
+		expected := []time.Duration{
+			1010 * time.Millisecond, // 1s + 10ms
+			1020 * time.Millisecond, // 1s + 20ms
+			1040 * time.Millisecond, // 1s + 40ms
+			1080 * time.Millisecond, // 1s + 80ms
+			1100 * time.Millisecond, // 1s + 160ms, capped
+		}
+		for i, exp := range expected {
+			d, _ := e.Next()
+			if d != exp {
+				t.Errorf("Call %d: expected %v, got %v", i+1, exp, d)
+			}
+			if at := e.DelayAt(i); at != exp {
+				t.Errorf("DelayAt(%d): expected %v, got %v", i, exp, at)
+			}
+		}
+
+		// Unlike the min interval, the shift is visible from the start
+		m := NewExponential(10*time.Millisecond, 2.0, WithMinInterval(time.Second))
+		d1, _ := m.Next()
+		d2, _ := m.Next()
+		if d1 != d2 {
+			t.Errorf("Expected min interval to flatten early delays, got %v and %v", d1, d2)
+		}
+	})
+
+	t.Run("with even distribution", func(t *testing.T) {
+		budget := time.Minute
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithMaxRetries(10),
+			WithMaxElapsed(budget),
+			WithEvenDistribution())
+
+		attempts := 0
+		var total, prev time.Duration
+		for {
+			d, ok := e.Next()
+			if !ok {
+				break
+			}
+			if prev > 0 && d != 2*prev {
+				t.Errorf("Attempt %d: expected growth factor to be kept, got %v after %v", attempts, d, prev)
+			}
+			attempts++
+			total += d
+			prev = d
+		}
+
+		if attempts != 10 {
+			t.Errorf("Expected all 10 attempts within the budget, got %d", attempts)
+		}
+		if total >= budget || total < budget*99/100 {
+			t.Errorf("Expected the delays to nearly fill the budget %v, got %v", budget, total)
+		}
+
+		// Without both limits the option has no effect
+		e = NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(10), WithEvenDistribution())
+		if d, _ := e.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected base delay without a max elapsed, got %v", d)
+		}
+	})
+
+	t.Run("with fill budget", func(t *testing.T) {
+		base := time.Second
+		maxElapsed := 10 * time.Second
+
+		// Without the option: 1s, 2s, 4s, then 8s overshoots and 3s are wasted
+		e := NewExponential(base, 2.0, WithMaxElapsed(maxElapsed))
+		var total time.Duration
+		for {
+			d, ok := e.Next()
+			if !ok {
+				break
+			}
+			total += d
+		}
+		if total != 7*time.Second {
+			t.Errorf("Expected 7s total without fill budget, got %v", total)
+		}
+
+		e = NewExponential(base, 2.0, WithMaxElapsed(maxElapsed), WithFillBudget())
+		var delays []time.Duration
+		for {
+			d, ok := e.Next()
+			if !ok {
+				break
+			}
+			delays = append(delays, d)
+		}
+
+		expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 3 * time.Second}
+		if len(delays) != len(expected) {
+			t.Fatalf("Expected %d delays, got %v", len(expected), delays)
+		}
+		for i, exp := range expected {
+			if delays[i] != exp {
+				t.Errorf("Call %d: expected %v, got %v", i+1, exp, delays[i])
+			}
+		}
+	})
+
 	t.Run("reset functionality", func(t *testing.T) {
 		base := 10 * time.Millisecond
 		factor := 2.0
@@ -282,13 +413,13 @@ func TestDecorrelated(t *testing.T) {
 		source := rand.NewPCG(42, 1024)
 		d := NewDecorrelated(initial, factor, WithRandSource(source))
 
-		// First call should return initial value
+		// First call should return a value up to the initial value
 		d1, ok := d.Next()
 		if !ok {
 			t.Fatal("First Next() call should succeed")
 		}
-		if d1 != initial {
-			t.Errorf("First call: expected %v, got %v", initial, d1)
+		if d1 < 0 || d1 > initial {
+			t.Errorf("First call: expected value in [0, %v], got %v", initial, d1)
 		}
 
 		// Subsequent calls should be randomized
@@ -336,6 +467,68 @@ func TestDecorrelated(t *testing.T) {
 		}
 	})
 
+	t.Run("first attempt is randomized", func(t *testing.T) {
+		initial := 100 * time.Millisecond
+		minInterval := 10 * time.Millisecond
+
+		seen := make(map[time.Duration]bool)
+		for seed := uint64(0); seed < 10; seed++ {
+			d := NewDecorrelated(initial, 3.0,
+				WithMinInterval(minInterval),
+				WithRandSource(rand.NewPCG(seed, seed)))
+
+			d1, ok := d.Next()
+			if !ok {
+				t.Fatal("First Next() call should succeed")
+			}
+			if d1 < minInterval || d1 > initial {
+				t.Errorf("Seed %d: first value %v outside [%v, %v]", seed, d1, minInterval, initial)
+			}
+			seen[d1] = true
+		}
+
+		if len(seen) < 2 {
+			t.Error("First attempt should vary across instances with different seeds")
+		}
+	})
+
+	t.Run("with flat cap", func(t *testing.T) {
+		minInterval := 500 * time.Millisecond
+		maxInterval := time.Second
+
+		d := NewDecorrelated(100*time.Millisecond, 3.0,
+			WithMinInterval(minInterval),
+			WithMaxInterval(maxInterval),
+			WithRandSource(rand.NewPCG(42, 1024)),
+			WithFlatCap())
+
+		// Grow until the cap is reached
+		for i := 0; i < 5 && !d.capped; i++ {
+			d.Next()
+		}
+		if !d.capped {
+			t.Fatal("Expected the cap to be reached")
+		}
+
+		// Steady state stays within [minInterval, maxInterval] and spans it
+		var lo, hi time.Duration = maxInterval, minInterval
+		for i := 0; i < 200; i++ {
+			v, _ := d.Next()
+			if v < minInterval || v > maxInterval {
+				t.Fatalf("Steady state value %v outside [%v, %v]", v, minInterval, maxInterval)
+			}
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		if lo > minInterval+50*time.Millisecond || hi < maxInterval-50*time.Millisecond {
+			t.Errorf("Expected steady state to span the range, got [%v, %v]", lo, hi)
+		}
+
+		d.Reset()
+		if d.capped {
+			t.Error("Reset() should clear the cap state")
+		}
+	})
+
 	t.Run("factor validation", func(t *testing.T) {
 		initial := 100 * time.Millisecond
 		d := NewDecorrelated(initial, 0.5) // Invalid factor
@@ -397,6 +590,41 @@ func TestDecorrelated(t *testing.T) {
 			t.Error("Next() should succeed after Reset()")
 		}
 	})
+
+	t.Run("decay", func(t *testing.T) {
+		dcr := NewDecorrelated(100*time.Millisecond, 3.0,
+			WithMinInterval(50*time.Millisecond),
+			WithMaxInterval(time.Second),
+			WithFlatCap())
+		dcr.Decay(0.5)
+		if dcr.prev != 0 {
+			t.Errorf("Expected no effect before the first delay, got %v", dcr.prev)
+		}
+
+		dcr.prev, dcr.retries, dcr.capped = 800*time.Millisecond, 5, true
+		dcr.Decay(0.5)
+		if dcr.prev != 400*time.Millisecond || dcr.retries != 5 || dcr.capped {
+			t.Errorf("Expected prev 400ms with 5 retries and cap cleared, got %v, %d, %v", dcr.prev, dcr.retries, dcr.capped)
+		}
+
+		dcr.Decay(0.01)
+		if dcr.prev != 100*time.Millisecond {
+			t.Errorf("Expected prev clamped to initial 100ms, got %v", dcr.prev)
+		}
+
+		dcr.prev = 800 * time.Millisecond
+		dcr.Decay(1.5)
+		if dcr.prev != 800*time.Millisecond {
+			t.Errorf("Expected factor >= 1 to have no effect, got %v", dcr.prev)
+		}
+
+		small := NewDecorrelated(10*time.Millisecond, 3.0, WithMinInterval(50*time.Millisecond))
+		small.Next()
+		small.Decay(0)
+		if small.prev != 50*time.Millisecond {
+			t.Errorf("Expected prev clamped to min interval 50ms, got %v", small.prev)
+		}
+	})
 }
 
 func TestJitterStrategies(t *testing.T) {
@@ -448,6 +676,144 @@ func TestJitterStrategies(t *testing.T) {
 		}
 	})
 
+	t.Run("ProportionalJitter", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(42, 1024))
+		d := 100 * time.Millisecond
+
+		tests := []struct {
+			fraction  float64
+			low, high time.Duration
+		}{
+			{0.2, 80 * time.Millisecond, 120 * time.Millisecond},
+			{0, d, d},
+			{1, 0, 2 * d},
+			{-0.5, d, d},       // clamped to 0
+			{3, 0, 2 * d},      // clamped to 1
+			{math.NaN(), d, d}, // no jitter
+		}
+		for _, tt := range tests {
+			jitter := ProportionalJitter{Fraction: tt.fraction}
+			var below, above bool
+			for i := 0; i < 10000; i++ {
+				got := jitter.Apply(d, r)
+				if got < tt.low || got > tt.high {
+					t.Fatalf("Fraction %v: %v not in range [%v, %v]", tt.fraction, got, tt.low, tt.high)
+				}
+				below = below || got < d
+				above = above || got > d
+			}
+			if tt.low < tt.high && (!below || !above) {
+				t.Errorf("Fraction %v: expected delays on both sides of %v", tt.fraction, d)
+			}
+		}
+
+		jitter := ProportionalJitter{Fraction: 0.2}
+		if got := jitter.Apply(0, r); got != 0 {
+			t.Errorf("Expected 0 for zero duration, got %v", got)
+		}
+		if got := jitter.Apply(-d, r); got != 0 {
+			t.Errorf("Expected 0 for negative duration, got %v", got)
+		}
+		if got := jitter.Apply(time.Duration(math.MaxInt64), r); got < 0 {
+			t.Errorf("Expected no overflow, got %v", got)
+		}
+	})
+
+	t.Run("JitterFunc", func(t *testing.T) {
+		calls := 0
+		j := JitterFunc(func(d time.Duration, r *rand.Rand) time.Duration {
+			if r == nil {
+				t.Error("Expected the strategy's random generator")
+			}
+			calls++
+			return d / 2
+		})
+		e := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(j))
+
+		if got, _ := e.Next(); got != 50*time.Millisecond {
+			t.Errorf("Expected the jittered 50ms, got %v", got)
+		}
+		e.Next()
+		e.Next()
+		if calls != 3 {
+			t.Errorf("Expected the func to be invoked once per delay, got %d calls", calls)
+		}
+	})
+
+	t.Run("SlottedJitter", func(t *testing.T) {
+		const total = 8
+		d := time.Second
+
+		// Distinct, evenly spaced slots across the fleet
+		for i := 0; i < total; i++ {
+			want := time.Duration(i) * d / total
+			if got := (SlottedJitter{Index: i, Total: total}).Apply(d, nil); got != want {
+				t.Errorf("Index %d: expected %v, got %v", i, want, got)
+			}
+		}
+
+		// Deterministic, and independent of the random source
+		j := SlottedJitter{Index: 5, Total: total}
+		if a, b := j.Apply(d, rand.New(rand.NewPCG(1, 2))), j.Apply(d, rand.New(rand.NewPCG(3, 4))); a != b {
+			t.Errorf("Expected identical results, got %v and %v", a, b)
+		}
+
+		// Indexes out of range wrap around
+		if got := (SlottedJitter{Index: total + 1, Total: total}).Apply(d, nil); got != d/total {
+			t.Errorf("Expected index 9 of 8 to wrap to slot 1 (%v), got %v", d/total, got)
+		}
+		if got := (SlottedJitter{Index: -1, Total: total}).Apply(d, nil); got != 7*d/total {
+			t.Errorf("Expected index -1 of 8 to wrap to slot 7 (%v), got %v", 7*d/total, got)
+		}
+
+		// No overflow for large delays
+		huge := time.Duration(math.MaxInt64)
+		if got := (SlottedJitter{Index: total - 1, Total: total}).Apply(huge, nil); got <= 0 || got > huge {
+			t.Errorf("Expected a value in (0, %v], got %v", huge, got)
+		}
+
+		if got := (SlottedJitter{}).Apply(d, nil); got != d {
+			t.Errorf("Expected the delay unchanged without a fleet size, got %v", got)
+		}
+	})
+
+	t.Run("full jitter bounds", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(42, 1024))
+		d := 3 * time.Nanosecond
+
+		tests := []struct {
+			name     string
+			jitter   Jitter
+			expected []time.Duration
+		}{
+			{"FullJitter", FullJitter{}, []time.Duration{1, 2, 3}},
+			{"FullJitterFromZero", FullJitterFromZero{}, []time.Duration{0, 1, 2, 3}},
+		}
+
+		for _, tt := range tests {
+			seen := make(map[time.Duration]int)
+			for i := 0; i < 4000; i++ {
+				seen[tt.jitter.Apply(d, r)]++
+			}
+			if len(seen) != len(tt.expected) {
+				t.Errorf("%s: expected values %v, got %v", tt.name, tt.expected, seen)
+			}
+			for _, v := range tt.expected {
+				// Uniform: each value about 4000/len(expected) times
+				if want := 4000 / len(tt.expected); seen[v] < want*8/10 || seen[v] > want*12/10 {
+					t.Errorf("%s: value %v drawn %d times, expected about %d", tt.name, v, seen[v], want)
+				}
+			}
+		}
+
+		if got := (FullJitterFromZero{}).Apply(time.Duration(math.MaxInt64), r); got < 0 {
+			t.Errorf("FullJitterFromZero: expected non-negative result for the max duration, got %v", got)
+		}
+		if got := (FullJitterFromZero{}).Apply(-time.Second, r); got != 0 {
+			t.Errorf("FullJitterFromZero: expected 0 for a negative duration, got %v", got)
+		}
+	})
+
 	t.Run("EqualJitter", func(t *testing.T) {
 		jitter := &EqualJitter{}
 		duration := 100 * time.Millisecond
@@ -479,9 +845,360 @@ func TestJitterStrategies(t *testing.T) {
 			t.Errorf("EqualJitter with negative duration should return 0, got %v", result)
 		}
 	})
+
+	t.Run("BetaJitter", func(t *testing.T) {
+		duration := 100 * time.Millisecond
+		r := rand.New(rand.NewPCG(42, 1024))
+
+		params := []struct{ alpha, beta float64 }{
+			{1, 1}, // uniform
+			{2, 5}, // skewed early
+			{5, 2}, // skewed late
+			{0.5, 0.5},
+		}
+
+		const samples = 20000
+		for _, p := range params {
+			jitter := BetaJitter{Alpha: p.alpha, Beta: p.beta}
+
+			var sum float64
+			for i := 0; i < samples; i++ {
+				result := jitter.Apply(duration, r)
+				if result < 0 || result > duration {
+					t.Fatalf("BetaJitter(%v, %v) result %v not in range [0, %v]", p.alpha, p.beta, result, duration)
+				}
+				sum += float64(result)
+			}
+
+			mean := sum / samples
+			expected := float64(duration) * p.alpha / (p.alpha + p.beta)
+			if diff := mean - expected; diff > 0.02*float64(duration) || diff < -0.02*float64(duration) {
+				t.Errorf("BetaJitter(%v, %v) mean %v, expected about %v", p.alpha, p.beta, time.Duration(mean), time.Duration(expected))
+			}
+		}
+
+		// Non-positive parameters default to uniform
+		jitter := BetaJitter{}
+		var sum float64
+		for i := 0; i < samples; i++ {
+			sum += float64(jitter.Apply(duration, r))
+		}
+		if mean := sum / samples; mean < 0.48*float64(duration) || mean > 0.52*float64(duration) {
+			t.Errorf("BetaJitter with default parameters mean %v, expected about %v", time.Duration(mean), duration/2)
+		}
+
+		// NaN and infinite parameters default to uniform as well
+		for _, p := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			jitter := BetaJitter{Alpha: p, Beta: p}
+			var sum float64
+			for i := 0; i < samples; i++ {
+				sum += float64(jitter.Apply(duration, r))
+			}
+			if mean := sum / samples; mean < 0.48*float64(duration) || mean > 0.52*float64(duration) {
+				t.Errorf("BetaJitter(%v, %v) mean %v, expected about %v", p, p, time.Duration(mean), duration/2)
+			}
+		}
+
+		// Test with zero and negative duration
+		if result := jitter.Apply(0, r); result != 0 {
+			t.Errorf("BetaJitter with zero duration should return 0, got %v", result)
+		}
+		if result := jitter.Apply(-10*time.Millisecond, r); result != 0 {
+			t.Errorf("BetaJitter with negative duration should return 0, got %v", result)
+		}
+	})
+
+	t.Run("ElapsedProportionalJitter", func(t *testing.T) {
+		jitter := ElapsedProportionalJitter{Fraction: 0.1}
+		r := rand.New(rand.NewPCG(42, 1024))
+		d := time.Second
+
+		if got := jitter.Apply(d, r); got != d {
+			t.Errorf("Apply without elapsed time should return %v, got %v", d, got)
+		}
+		if got := jitter.ApplyWithElapsed(d, 0, r); got != d {
+			t.Errorf("No elapsed time should mean no spread, got %v", got)
+		}
+
+		// The spread grows with the elapsed time
+		for _, elapsed := range []time.Duration{time.Second, time.Minute, time.Hour} {
+			spread := elapsed / 10
+			var lowest, highest time.Duration = math.MaxInt64, 0
+			for i := 0; i < 1000; i++ {
+				got := jitter.ApplyWithElapsed(d, elapsed, r)
+				if got < max(d-spread, 0) || got > d+spread {
+					t.Fatalf("Elapsed %v: %v outside [%v, %v]", elapsed, got, max(d-spread, 0), d+spread)
+				}
+				lowest, highest = min(lowest, got), max(highest, got)
+			}
+			if highest-lowest < spread {
+				t.Errorf("Elapsed %v: expected a spread of about %v, got %v", elapsed, 2*spread, highest-lowest)
+			}
+		}
+
+		if result := jitter.ApplyWithElapsed(-10*time.Millisecond, time.Hour, r); result != 0 {
+			t.Errorf("ElapsedProportionalJitter with negative duration should return 0, got %v", result)
+		}
+	})
+}
+
+func TestJitterByName(t *testing.T) {
+	for _, name := range []string{"none", "full", "full-from-zero", "equal", "beta", "elapsed-proportional", "adaptive", "deterministic"} {
+		t.Run(name, func(t *testing.T) {
+			j, err := JitterByName(name)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := jitterName(j); got != name {
+				t.Errorf("Expected round trip to %q, got %q", name, got)
+			}
+		})
+	}
+
+	a, _ := JitterByName("adaptive")
+	b, _ := JitterByName("adaptive")
+	if a == b {
+		t.Error("Expected a new AdaptiveJitter on each call")
+	}
+
+	if _, err := JitterByName("gaussian"); err == nil {
+		t.Error("Expected an error for an unknown name")
+	}
 }
 
 func TestOptions(t *testing.T) {
+	t.Run("WithFastRand", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithJitterStrategy(FullJitter{}),
+			WithFastRand())
+
+		seen := make(map[time.Duration]bool)
+		for i := 0; i < 100; i++ {
+			e.Reset()
+			d, ok := e.Next()
+			if !ok || d < 1 || d > 100*time.Millisecond {
+				t.Fatalf("Expected a delay in [1ns, 100ms], got (%v, %v)", d, ok)
+			}
+			seen[d] = true
+		}
+		if len(seen) < 90 {
+			t.Errorf("Expected random delays, got only %d distinct values", len(seen))
+		}
+
+		// Strategies on concurrent goroutines draw from the same generator
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				e := NewExponential(time.Millisecond, 2.0, WithJitter(), WithFastRand(), WithMaxRetries(100))
+				for {
+					if _, ok := e.Next(); !ok {
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("WithHealthScore", func(t *testing.T) {
+		tests := []struct {
+			score float64
+			want  time.Duration
+		}{
+			{0, 800 * time.Millisecond},
+			{1, 100 * time.Millisecond},
+			{0.5, 450 * time.Millisecond},
+			{-1, 800 * time.Millisecond}, // clamped to 0
+			{2, 100 * time.Millisecond},  // clamped to 1
+			{math.NaN(), 800 * time.Millisecond},
+		}
+		for _, tt := range tests {
+			e := NewExponential(200*time.Millisecond, 2.0,
+				WithMinInterval(100*time.Millisecond),
+				WithHealthScore(func() float64 { return tt.score }))
+			e.Next()
+			e.Next()
+			if got, _ := e.Next(); got != tt.want {
+				t.Errorf("Score %v: expected %v, got %v", tt.score, tt.want, got)
+			}
+		}
+
+		// Read per call, without affecting growth
+		score := 1.0
+		e := NewExponential(100*time.Millisecond, 2.0, WithHealthScore(func() float64 { return score }))
+		if got, _ := e.Next(); got != 0 {
+			t.Errorf("Expected no delay when healthy without a min interval, got %v", got)
+		}
+		score = 0
+		if got, _ := e.Next(); got != 200*time.Millisecond {
+			t.Errorf("Expected the full second delay when unhealthy, got %v", got)
+		}
+	})
+
+	t.Run("WithRandSource nil", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0, WithRandSource(nil), WithJitter())
+		want := NewExponential(100*time.Millisecond, 2.0, WithJitter())
+		for i := 0; i < 5; i++ {
+			got, ok := e.Next()
+			if w, _ := want.Next(); !ok || got != w {
+				t.Fatalf("Call %d: expected the default source to be used (%v, true), got (%v, %v)", i+1, w, got, ok)
+			}
+		}
+
+		source := rand.NewPCG(1, 2)
+		e = NewExponential(100*time.Millisecond, 2.0, WithRandSource(source), WithRandSource(nil), WithJitter())
+		want = NewExponential(100*time.Millisecond, 2.0, WithRandSource(rand.NewPCG(1, 2)), WithJitter())
+		got, _ := e.Next()
+		if w, _ := want.Next(); got != w {
+			t.Errorf("Expected the earlier source to be kept, got %v instead of %v", got, w)
+		}
+	})
+
+	t.Run("WithContextDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		c := NewConstant(time.Second, WithContextDeadline(ctx))
+		if limit := c.options.maxElapsed; limit <= 59*time.Second || limit > time.Minute {
+			t.Errorf("Expected a limit of just under 1m, got %v", limit)
+		}
+
+		// No deadline, no limit
+		c = NewConstant(time.Second, WithContextDeadline(context.Background()))
+		if limit := c.options.maxElapsed; limit != 0 {
+			t.Errorf("Expected no limit without a deadline, got %v", limit)
+		}
+		c = NewConstant(time.Second, WithMaxElapsed(time.Hour), WithContextDeadline(context.Background()))
+		if limit := c.options.maxElapsed; limit != time.Hour {
+			t.Errorf("Expected the earlier limit to be kept without a deadline, got %v", limit)
+		}
+
+		// Deadline passed
+		expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		if _, ok := NewConstant(time.Second, WithContextDeadline(expired)).Next(); ok {
+			t.Error("Expected no retries after the deadline")
+		}
+		if _, ok := NewConstant(time.Second, WithContextDeadline(expired), WithMaxRetries(5)).Next(); ok {
+			t.Error("Expected a later WithMaxRetries not to allow retries after the deadline")
+		}
+
+		// Measured with the configured clock, in any option order
+		epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		fixed, cancel := context.WithDeadline(context.Background(), epoch.Add(30*time.Second))
+		defer cancel()
+		c = NewConstant(time.Second, WithContextDeadline(fixed), WithClock(&fakeClock{now: epoch}))
+		if limit := c.options.maxElapsed; limit != 30*time.Second {
+			t.Errorf("Expected a limit of 30s on the fake clock, got %v", limit)
+		}
+		c = NewConstant(time.Second, WithClock(&fakeClock{now: epoch.Add(time.Minute)}), WithContextDeadline(fixed))
+		if _, ok := c.Next(); ok {
+			t.Error("Expected no retries once the fake clock is past the deadline")
+		}
+
+		// The smaller of both limits applies
+		c = NewConstant(time.Second, WithContextDeadline(fixed), WithMaxElapsed(10*time.Second), WithClock(&fakeClock{now: epoch}))
+		if limit := c.options.maxElapsed; limit != 10*time.Second {
+			t.Errorf("Expected the smaller limit of 10s, got %v", limit)
+		}
+
+		// Stops once the delays add up to the deadline
+		short, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+		defer cancel()
+		c = NewConstant(time.Second, WithContextDeadline(short))
+		for i := 0; i < 3; i++ {
+			if _, ok := c.Next(); !ok {
+				t.Fatalf("Call %d: expected a delay within the deadline", i+1)
+			}
+		}
+		if _, ok := c.Next(); ok {
+			t.Error("Expected no delay once 3s have elapsed")
+		}
+	})
+
+	t.Run("WithTickAlignment", func(t *testing.T) {
+		e := NewExponential(time.Second, 2.0,
+			WithTickAlignment(5*time.Second, 200*time.Millisecond))
+
+		// Rounded up to the grid 0.2s, 5.2s, 10.2s, ...
+		expected := []time.Duration{
+			5200 * time.Millisecond,  // 1s
+			5200 * time.Millisecond,  // 2s
+			5200 * time.Millisecond,  // 4s
+			10200 * time.Millisecond, // 8s
+			20200 * time.Millisecond, // 16s
+		}
+		for i, want := range expected {
+			if got, _ := e.Next(); got != want {
+				t.Errorf("Call %d: expected %v, got %v", i+1, want, got)
+			}
+		}
+
+		// Delays on the grid are unchanged
+		c := NewConstant(5200*time.Millisecond, WithTickAlignment(5*time.Second, -4800*time.Millisecond))
+		if got, _ := c.Next(); got != 5200*time.Millisecond {
+			t.Errorf("Expected a delay on the grid to be unchanged, got %v", got)
+		}
+
+		// Jittered delays land on the grid too
+		e = NewExponential(time.Second, 2.0, WithJitter(), WithTickAlignment(time.Second, 0))
+		for i := 0; i < 20; i++ {
+			if got, _ := e.Next(); got%time.Second != 0 {
+				t.Fatalf("Expected a multiple of 1s, got %v", got)
+			}
+		}
+
+		// A non-positive period disables alignment
+		c = NewConstant(1500*time.Millisecond, WithTickAlignment(0, time.Second))
+		if got, _ := c.Next(); got != 1500*time.Millisecond {
+			t.Errorf("Expected no alignment, got %v", got)
+		}
+	})
+
+	t.Run("WithFirstDelayRange", func(t *testing.T) {
+		e := NewExponential(200*time.Millisecond, 2.0,
+			WithFirstDelayRange(50*time.Millisecond, 100*time.Millisecond))
+
+		// The first delay is clamped, later ones follow the normal schedule
+		for _, want := range []time.Duration{100 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond} {
+			if got, _ := e.Next(); got != want {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		}
+		e.Reset()
+		if got, _ := e.Next(); got != 100*time.Millisecond {
+			t.Errorf("Expected the first delay to be clamped again after Reset, got %v", got)
+		}
+		if got := e.DelayAt(0); got != 100*time.Millisecond {
+			t.Errorf("Expected DelayAt(0) to be clamped, got %v", got)
+		}
+
+		// Randomized within the window with jitter, raised to its lower edge
+		e = NewExponential(10*time.Millisecond, 2.0, WithJitter(),
+			WithFirstDelayRange(50*time.Millisecond, 100*time.Millisecond))
+		if got, _ := e.Next(); got != 50*time.Millisecond {
+			t.Errorf("Expected the first delay raised to 50ms, got %v", got)
+		}
+		e = NewExponential(80*time.Millisecond, 2.0, WithJitter(),
+			WithFirstDelayRange(100*time.Millisecond, 20*time.Millisecond)) // swapped
+		for i := 0; i < 100; i++ {
+			e.Reset()
+			if got, _ := e.Next(); got < 20*time.Millisecond || got > 100*time.Millisecond {
+				t.Fatalf("Expected the first delay in [20ms, 100ms], got %v", got)
+			}
+		}
+
+		d := NewDecorrelated(time.Second, 3.0, WithFirstDelayRange(0, 10*time.Millisecond))
+		if got, _ := d.Next(); got > 10*time.Millisecond {
+			t.Errorf("Expected the first decorrelated delay <= 10ms, got %v", got)
+		}
+		if got, _ := d.Next(); got < time.Second {
+			t.Errorf("Expected the second decorrelated delay >= base, got %v", got)
+		}
+	})
+
 	t.Run("WithMaxRetries", func(t *testing.T) {
 		c := NewConstant(10*time.Millisecond, WithMaxRetries(2))
 
@@ -571,10 +1288,450 @@ func TestOptions(t *testing.T) {
 		}
 	})
 
-	t.Run("multiple options", func(t *testing.T) {
-		// Note: Constant doesn't apply min/max interval bounds to its fixed interval
-		// So we test with exponential instead
-		e := NewExponential(50*time.Millisecond, 2.0,
+	t.Run("WithDeterministicJitter", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithDeterministicJitter(0.75),
+			WithJitterStrategy(&FullJitter{}))
+
+		// Exponential grows from the jittered delay
+		expected := []time.Duration{75 * time.Millisecond, 112500 * time.Microsecond, 168750 * time.Microsecond}
+		for i, want := range expected {
+			d, ok := e.Next()
+			if !ok || d != want {
+				t.Errorf("Attempt %d: expected %v, got %v (ok=%v)", i, want, d, ok)
+			}
+		}
+
+		if spread := e.JitterSpread(10); spread != 0 {
+			t.Errorf("Expected no jitter spread, got %v", spread)
+		}
+
+		c := NewConstant(time.Second, WithDeterministicJitter(-1))
+		if d, _ := c.Next(); d != time.Second {
+			t.Errorf("Constant ignores jitter, expected %v, got %v", time.Second, d)
+		}
+	})
+
+	t.Run("WithElapsedJitter", func(t *testing.T) {
+		limit := 10 * time.Second
+		limits := make(map[time.Duration]bool)
+		for seed := uint64(0); seed < 10; seed++ {
+			c := NewConstant(time.Second,
+				WithRandSource(rand.NewPCG(seed, seed)),
+				WithMaxElapsed(limit),
+				WithElapsedJitter(0.5))
+
+			got := c.options.maxElapsed
+			if got < limit/2 || got > limit*3/2 {
+				t.Errorf("Seed %d: effective limit %v outside [%v, %v]", seed, got, limit/2, limit*3/2)
+			}
+			limits[got] = true
+
+			// Same seed, same limit
+			again := NewConstant(time.Second,
+				WithRandSource(rand.NewPCG(seed, seed)),
+				WithMaxElapsed(limit),
+				WithElapsedJitter(0.5))
+			if again.options.maxElapsed != got {
+				t.Errorf("Seed %d: expected reproducible limit %v, got %v", seed, got, again.options.maxElapsed)
+			}
+		}
+		if len(limits) < 2 {
+			t.Error("Expected the effective limit to vary across seeds")
+		}
+
+		// Without WithRandSource, instances do not share the default seed
+		limits = make(map[time.Duration]bool)
+		for i := 0; i < 10; i++ {
+			c := NewConstant(time.Second, WithMaxElapsed(limit), WithElapsedJitter(0.5))
+			limits[c.options.maxElapsed] = true
+		}
+		if len(limits) < 2 {
+			t.Error("Expected the effective limit to vary across default instances")
+		}
+
+		if c := NewConstant(time.Second, WithMaxElapsed(limit), WithElapsedJitter(math.NaN())); c.options.maxElapsed != limit {
+			t.Errorf("Expected NaN fraction to keep the limit %v, got %v", limit, c.options.maxElapsed)
+		}
+
+		c := NewConstant(time.Second, WithElapsedJitter(0.5))
+		if c.options.maxElapsed != 0 {
+			t.Errorf("Expected no limit without WithMaxElapsed, got %v", c.options.maxElapsed)
+		}
+	})
+
+	t.Run("WithResetHook", func(t *testing.T) {
+		calls := 0
+		hook := WithResetHook(func() { calls++ })
+
+		strategies := []Sequence{
+			NewConstant(time.Second, hook, WithRandomStart(2)),
+			NewExponential(time.Second, 2.0, hook),
+			NewDecorrelated(time.Second, 3.0, hook),
+			NewHybrid(time.Second, time.Second, 2, 2.0, hook),
+			NewWeightedRandom([]WeightedDelay{{Delay: time.Second, Weight: 1}}, hook),
+			NewDeadline(time.Minute, 3, hook),
+		}
+		if calls != 0 {
+			t.Fatalf("Hook should not fire during construction, fired %d times", calls)
+		}
+
+		for i, s := range strategies {
+			s.Next()
+			s.Reset()
+			if calls != i+1 {
+				t.Errorf("Strategy %d: expected hook to fire once per Reset(), got %d calls", i, calls)
+			}
+		}
+
+		e := NewExponential(time.Second, 2.0, hook)
+		e.Next()
+		e.SoftReset()
+		if calls != len(strategies) {
+			t.Error("Hook should not fire on SoftReset()")
+		}
+	})
+
+	t.Run("WithResetCooldown", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		resets := 0
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithClock(clock),
+			WithResetCooldown(time.Second),
+			WithResetHook(func() { resets++ }))
+
+		advance := func() {
+			e.Next()
+			e.Next()
+		}
+
+		// First reset always takes effect
+		advance()
+		e.Reset()
+		if d, _ := e.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected first reset to take effect, got %v", d)
+		}
+
+		// Rapid resets within the cooldown are ignored
+		for i := 0; i < 5; i++ {
+			clock.Advance(100 * time.Millisecond)
+			e.Reset()
+		}
+		if d, _ := e.Next(); d != 200*time.Millisecond {
+			t.Errorf("Expected resets within cooldown to be ignored, got %v", d)
+		}
+
+		// Measured from the last effective reset, not the last call
+		clock.Advance(500 * time.Millisecond)
+		e.Reset()
+		if d, _ := e.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected reset after cooldown to take effect, got %v", d)
+		}
+		if resets != 2 {
+			t.Errorf("Expected hook to fire on effective resets only, got %d calls", resets)
+		}
+	})
+
+	t.Run("WithSaneDefaults", func(t *testing.T) {
+		e := NewExponential(time.Second, 2.0, WithSaneDefaults())
+		if d := e.DelayAt(100); d != 30*time.Second {
+			t.Errorf("Expected default max interval 30s, got %v", d)
+		}
+
+		e = NewExponential(time.Second, 2.0, WithMaxInterval(time.Minute), WithSaneDefaults())
+		if d := e.DelayAt(100); d != time.Minute {
+			t.Errorf("Expected explicit max interval to take precedence, got %v", d)
+		}
+
+		// Limited retries are left alone
+		e = NewExponential(time.Second, 2.0, WithSaneDefaults(), WithMaxRetries(10))
+		if d := e.DelayAt(9); d != 512*time.Second {
+			t.Errorf("Expected no cap with limited retries, got %v", d)
+		}
+	})
+
+	t.Run("WithMillisecondResolution", func(t *testing.T) {
+		res := WithMillisecondResolution()
+		strategies := []struct {
+			name     string
+			sequence Sequence
+		}{
+			{"Constant", NewConstant(1500*time.Microsecond, res)},
+			{"Exponential", NewExponential(3*time.Millisecond, 1.7, WithJitter(), res)},
+			{"Decorrelated", NewDecorrelated(3*time.Millisecond, 3.0, res)},
+			{"Hybrid", NewHybrid(time.Millisecond, 333*time.Microsecond, 3, 1.5, WithJitter(), res)},
+			{"WeightedRandom", NewWeightedRandom([]WeightedDelay{{Delay: 2500 * time.Microsecond, Weight: 1}}, WithJitter(), res)},
+			{"Deadline", NewDeadline(time.Second, 7, WithDeadlineFactor(1.3), res)},
+			{"Pacer", NewPacer(10*time.Millisecond, 5*time.Millisecond, res)},
+			{"Probing", NewProbing(1300*time.Microsecond, 1.9, 9*time.Millisecond, 2, 1500*time.Microsecond, res)},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				for i := 0; i < 7; i++ {
+					d, ok := strategy.sequence.Next()
+					if !ok {
+						break
+					}
+					if d%time.Millisecond != 0 || d <= 0 {
+						t.Errorf("Call %d: %v is not a positive whole number of milliseconds", i+1, d)
+					}
+				}
+			})
+		}
+
+		// Never truncated to zero
+		if d, _ := NewConstant(10*time.Microsecond, res).Next(); d != time.Millisecond {
+			t.Errorf("Expected sub-millisecond delay to become 1ms, got %v", d)
+		}
+		if d, _ := NewExponential(0, 2.0, res).Next(); d != 0 {
+			t.Errorf("Expected zero delay to stay zero, got %v", d)
+		}
+
+		// Elapsed time is charged with the truncated delays
+		c := NewConstant(1900*time.Microsecond, res, WithMaxElapsed(3*time.Millisecond))
+		n := 0
+		for ; n < 10; n++ {
+			if _, ok := c.Next(); !ok {
+				break
+			}
+		}
+		if n != 3 {
+			t.Errorf("Expected 3 delays of 1ms within 3ms, got %d", n)
+		}
+	})
+
+	t.Run("WithFreeFirstAttempt", func(t *testing.T) {
+		count := func(s Sequence) (n int) {
+			for {
+				if _, ok := s.Next(); !ok {
+					return n
+				}
+				n++
+			}
+		}
+
+		budget := WithMaxElapsed(3 * time.Second)
+		if n := count(NewConstant(time.Second, budget)); n != 3 {
+			t.Errorf("Expected 3 retries without the flag, got %d", n)
+		}
+		if n := count(NewConstant(time.Second, budget, WithFreeFirstAttempt())); n != 4 {
+			t.Errorf("Expected 4 retries with the flag, got %d", n)
+		}
+
+		// The first delay is exempt even if it alone exceeds the budget
+		e := NewExponential(5*time.Second, 2.0, budget, WithFreeFirstAttempt())
+		if d, ok := e.Next(); !ok || d != 5*time.Second {
+			t.Errorf("Expected (5s, true) for the free first delay, got (%v, %v)", d, ok)
+		}
+		if _, ok := e.Next(); ok {
+			t.Error("Expected the second delay to be charged against the budget")
+		}
+
+		// Free again after Reset
+		e.Reset()
+		if _, ok := e.Next(); !ok {
+			t.Error("Expected the first delay after Reset() to be free")
+		}
+
+		// Retry limits still count the first delay
+		if n := count(NewConstant(time.Second, WithMaxRetries(2), WithFreeFirstAttempt())); n != 2 {
+			t.Errorf("Expected 2 retries, got %d", n)
+		}
+	})
+
+	t.Run("WithDynamicMaxInterval", func(t *testing.T) {
+		stepUp := WithDynamicMaxInterval(func(attempt int) time.Duration {
+			if attempt < 3 {
+				return 250 * time.Millisecond
+			}
+			return time.Second
+		})
+
+		e := NewExponential(100*time.Millisecond, 2.0, stepUp, WithMaxInterval(time.Hour))
+		want := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			250 * time.Millisecond, // early cap
+			800 * time.Millisecond, // growth is not distorted by the cap
+			time.Second,
+			time.Second,
+		}
+		for i, w := range want {
+			if d, _ := e.Next(); d != w {
+				t.Errorf("Attempt %d: expected %v, got %v", i, w, d)
+			}
+			if got := e.DelayAt(i); got != w {
+				t.Errorf("DelayAt(%d): expected %v, got %v", i, w, got)
+			}
+		}
+
+		// Overrides the default cap of Decorrelated, and applies after jitter
+		dcr := NewDecorrelated(time.Second, 3.0, stepUp, WithJitterStrategy(inflateJitter{}))
+		for i := 0; i < 6; i++ {
+			d, _ := dcr.Next()
+			limit := 250 * time.Millisecond
+			if i >= 3 {
+				limit = time.Second
+			}
+			if d > limit {
+				t.Errorf("Attempt %d: delay %v exceeds dynamic cap %v", i, d, limit)
+			}
+		}
+	})
+
+	t.Run("WithScale", func(t *testing.T) {
+		scale := 2.0
+		e := NewExponential(10*time.Millisecond, 2.0,
+			WithScale(func() float64 { return scale }))
+
+		d1, _ := e.Next()
+		if d1 != 20*time.Millisecond {
+			t.Errorf("Expected scaled delay 20ms, got %v", d1)
+		}
+
+		// Scale is read on every call and does not compound into growth
+		scale = 0.5
+		d2, _ := e.Next()
+		if d2 != 10*time.Millisecond {
+			t.Errorf("Expected scaled delay 10ms, got %v", d2)
+		}
+
+		// Out of range values are clamped
+		scale = 1000
+		d3, _ := e.Next()
+		if d3 != 40*time.Millisecond*maxScale {
+			t.Errorf("Expected delay clamped to %v, got %v", 40*time.Millisecond*maxScale, d3)
+		}
+
+		c := NewConstant(time.Second, WithScale(func() float64 { return 0 }))
+		d, _ := c.Next()
+		if d != 10*time.Millisecond {
+			t.Errorf("Expected delay clamped to 10ms, got %v", d)
+		}
+	})
+
+	t.Run("WithElapsedOffset", func(t *testing.T) {
+		// 250ms budget, 150ms already spent: one 100ms retry fits
+		c := NewConstant(100*time.Millisecond,
+			WithMaxElapsed(250*time.Millisecond),
+			WithElapsedOffset(150*time.Millisecond))
+
+		attempts := 0
+		for {
+			if _, ok := c.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt with pre-charged budget, got %d", attempts)
+		}
+
+		// Reset restores the offset rather than zeroing it
+		c.Reset()
+		attempts = 0
+		for {
+			if _, ok := c.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt after Reset(), got %d", attempts)
+		}
+	})
+
+	t.Run("WithRandomStart", func(t *testing.T) {
+		base := 100 * time.Millisecond
+		steps := map[time.Duration]int{base: 0, 2 * base: 1, 4 * base: 2, 8 * base: 3}
+
+		seen := make(map[time.Duration]bool)
+		for seed := uint64(0); seed < 20; seed++ {
+			e := NewExponential(base, 2.0,
+				WithRandSource(rand.NewPCG(seed, seed)),
+				WithRandomStart(3),
+				WithMaxRetries(5))
+
+			if _, ok := e.Current(); ok {
+				t.Error("Current() should not report the skipped steps")
+			}
+
+			d, ok := e.Next()
+			if !ok {
+				t.Fatalf("Seed %d: first Next() should succeed", seed)
+			}
+			skipped, valid := steps[d]
+			if !valid {
+				t.Fatalf("Seed %d: unexpected first delay %v", seed, d)
+			}
+			seen[d] = true
+
+			// Skipped steps reduce the retries left
+			attempts := 1
+			for {
+				if _, ok := e.Next(); !ok {
+					break
+				}
+				attempts++
+			}
+			if attempts != 5-skipped {
+				t.Errorf("Seed %d: expected %d attempts after skipping %d, got %d", seed, 5-skipped, skipped, attempts)
+			}
+
+			// Reset rewinds to the beginning
+			e.Reset()
+			if d, _ := e.Next(); d != base {
+				t.Errorf("Seed %d: expected %v after Reset(), got %v", seed, base, d)
+			}
+		}
+
+		if len(seen) < 2 {
+			t.Error("Expected the start to vary across seeds")
+		}
+
+		// Without WithRandSource, instances do not share the default seed
+		seen = make(map[time.Duration]bool)
+		for i := 0; i < 10; i++ {
+			d, _ := NewExponential(base, 2.0, WithRandomStart(20)).Next()
+			seen[d] = true
+		}
+		if len(seen) < 2 {
+			t.Error("Expected the start to vary across default instances")
+		}
+
+		// The skip advances the growth only: callbacks are not called, and
+		// the first delay returned is still the first one
+		for seed := uint64(0); seed < 20; seed++ {
+			scaleCalls := 0
+			e := NewExponential(base, 2.0,
+				WithRandSource(rand.NewPCG(seed, seed)),
+				WithRandomStart(3),
+				WithFirstDelayRange(10*time.Millisecond, 20*time.Millisecond),
+				WithFreeFirstAttempt(),
+				WithMaxElapsed(time.Second),
+				WithScale(func() float64 {
+					scaleCalls++
+					return 1
+				}))
+			if scaleCalls != 0 {
+				t.Errorf("Seed %d: expected no scale calls at construction, got %d", seed, scaleCalls)
+			}
+
+			d, ok := e.Next()
+			if !ok || d < 10*time.Millisecond || d > 20*time.Millisecond {
+				t.Errorf("Seed %d: expected a first delay in [10ms, 20ms], got (%v, %v)", seed, d, ok)
+			}
+			if got := e.Elapsed(); got != 0 {
+				t.Errorf("Seed %d: expected the first delay to be free, got elapsed %v", seed, got)
+			}
+		}
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		// Note: Constant doesn't apply min/max interval bounds to its fixed interval
+		// So we test with exponential instead
+		e := NewExponential(50*time.Millisecond, 2.0,
 			WithMaxRetries(3),
 			WithMaxElapsed(5*time.Second), // Large enough to not interfere
 			WithMinInterval(60*time.Millisecond))
@@ -608,6 +1765,7 @@ func TestSequenceInterface(t *testing.T) {
 		{"Constant", NewConstant(100 * time.Millisecond)},
 		{"Exponential", NewExponential(100*time.Millisecond, 2.0)},
 		{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0)},
+		{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0)},
 	}
 
 	for _, strategy := range strategies {
@@ -638,6 +1796,335 @@ func TestSequenceInterface(t *testing.T) {
 	}
 }
 
+func TestCurrent(t *testing.T) {
+	strategies := []struct {
+		name     string
+		sequence interface {
+			Sequence
+			Current() (time.Duration, bool)
+		}
+	}{
+		{"Constant", NewConstant(100*time.Millisecond, WithMaxRetries(2))},
+		{"Exponential", NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(2))},
+		{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0, WithMaxRetries(2))},
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategy.name, func(t *testing.T) {
+			s := strategy.sequence
+
+			if d, ok := s.Current(); ok || d != 0 {
+				t.Errorf("Current() before Next() should return (0, false), got (%v, %v)", d, ok)
+			}
+
+			d1, _ := s.Next()
+			for i := 0; i < 3; i++ {
+				d, ok := s.Current()
+				if !ok || d != d1 {
+					t.Errorf("Current() should return (%v, true) without advancing, got (%v, %v)", d1, d, ok)
+				}
+			}
+
+			// Current must not have consumed a retry
+			if _, ok := s.Next(); !ok {
+				t.Error("Second Next() should succeed with maxRetries=2")
+			}
+
+			// Exhausted
+			s.Next()
+			if d, ok := s.Current(); ok || d != 0 {
+				t.Errorf("Current() after exhaustion should return (0, false), got (%v, %v)", d, ok)
+			}
+
+			s.Next()
+			s.Reset()
+			if d, ok := s.Current(); ok || d != 0 {
+				t.Errorf("Current() after Reset() should return (0, false), got (%v, %v)", d, ok)
+			}
+		})
+	}
+}
+
+func TestNextDetailed(t *testing.T) {
+	type detailed interface {
+		Sequence
+		NextDetailed() (time.Duration, time.Duration, bool)
+	}
+
+	t.Run("result within jitter band of raw", func(t *testing.T) {
+		// EqualJitter returns a delay in [raw/2, raw]
+		strategies := []struct {
+			name     string
+			sequence detailed
+		}{
+			{"Exponential", NewExponential(100*time.Millisecond, 2.0, WithJitter(), WithMaxRetries(8))},
+			{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0, WithJitter(), WithMaxRetries(8))},
+			{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0, WithJitter(), WithMaxRetries(8))},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				for i := 0; ; i++ {
+					result, raw, ok := strategy.sequence.NextDetailed()
+					if !ok {
+						if i != 8 {
+							t.Errorf("Expected 8 delays, got %d", i)
+						}
+						if result != 0 || raw != 0 {
+							t.Errorf("Expected (0, 0) on exhaustion, got (%v, %v)", result, raw)
+						}
+						break
+					}
+					if result < raw/2 || result > raw {
+						t.Errorf("Call %d: result %v outside jitter band [%v, %v]", i+1, result, raw/2, raw)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("raw is before bounds", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0, WithMaxInterval(300*time.Millisecond))
+		want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}
+		for i, w := range want {
+			result, raw, _ := e.NextDetailed()
+			if raw != w {
+				t.Errorf("Call %d: expected raw %v, got %v", i+1, w, raw)
+			}
+			if result != min(w, 300*time.Millisecond) {
+				t.Errorf("Call %d: expected result %v, got %v", i+1, min(w, 300*time.Millisecond), result)
+			}
+		}
+	})
+
+	t.Run("Constant", func(t *testing.T) {
+		c := NewConstant(time.Second, WithScale(func() float64 { return 2 }))
+		if result, raw, ok := c.NextDetailed(); !ok || raw != time.Second || result != 2*time.Second {
+			t.Errorf("Expected (2s, 1s, true), got (%v, %v, %v)", result, raw, ok)
+		}
+	})
+
+	t.Run("shares state with Next", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0)
+		e.Next()
+		if _, raw, _ := e.NextDetailed(); raw != 200*time.Millisecond {
+			t.Errorf("Expected raw 200ms after one Next(), got %v", raw)
+		}
+		if d, _ := e.Next(); d != 400*time.Millisecond {
+			t.Errorf("Expected 400ms after NextDetailed(), got %v", d)
+		}
+	})
+}
+
+func TestSoftReset(t *testing.T) {
+	t.Run("Constant", func(t *testing.T) {
+		c := NewConstant(100*time.Millisecond, WithMaxRetries(4), WithMaxElapsed(time.Second))
+		for i := 0; i < 4; i++ {
+			c.Next()
+		}
+
+		c.SoftReset()
+		if _, ok := c.Current(); ok {
+			t.Error("Current() should be cleared by SoftReset()")
+		}
+
+		// Two retries are given back, and the 400ms already spent still count
+		for i := 0; i < 2; i++ {
+			if _, ok := c.Next(); !ok {
+				t.Fatalf("Next() call %d after SoftReset() should succeed", i+1)
+			}
+		}
+		if _, ok := c.Next(); ok {
+			t.Error("Expected the retry limit to be reached again")
+		}
+
+		c = NewConstant(300*time.Millisecond, WithMaxElapsed(time.Second))
+		for i := 0; i < 4; i++ {
+			c.Next()
+		}
+		c.SoftReset()
+		if _, ok := c.Next(); ok {
+			t.Error("Expected the elapsed time to be kept")
+		}
+	})
+
+	t.Run("Exponential", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0)
+		for i := 0; i < 4; i++ {
+			e.Next()
+		}
+
+		e.SoftReset()
+		expected := []time.Duration{400 * time.Millisecond, 800 * time.Millisecond}
+		for i, want := range expected {
+			if d, _ := e.Next(); d != want {
+				t.Errorf("Attempt %d after SoftReset(): expected %v, got %v", i, want, d)
+			}
+		}
+
+		// A single retry is rewound completely
+		e = NewExponential(100*time.Millisecond, 2.0)
+		e.Next()
+		e.SoftReset()
+		if d, _ := e.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected base after rewinding the only retry, got %v", d)
+		}
+	})
+
+	t.Run("Decorrelated", func(t *testing.T) {
+		dcr := NewDecorrelated(100*time.Millisecond, 3.0,
+			WithMaxInterval(200*time.Millisecond),
+			WithFlatCap())
+		for i := 0; i < 6; i++ {
+			dcr.Next()
+		}
+		prev := dcr.prev
+
+		dcr.SoftReset()
+		if dcr.retries != 3 {
+			t.Errorf("Expected 3 retries after SoftReset(), got %d", dcr.retries)
+		}
+		if dcr.capped {
+			t.Error("Expected the cap state to be cleared")
+		}
+		want := prev
+		for i := 0; i < 3; i++ {
+			want = time.Duration(float64(want) / 3.0)
+		}
+		if dcr.prev != want {
+			t.Errorf("Expected previous delay %v, got %v", want, dcr.prev)
+		}
+	})
+}
+
+func TestWithOverrides(t *testing.T) {
+	t.Run("applies overrides on top", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithMaxRetries(5),
+			WithMaxInterval(time.Second))
+		e.Next()
+		e.Next()
+
+		o := e.WithOverrides(WithMaxInterval(150 * time.Millisecond))
+
+		// Copy starts from its initial state
+		d1, _ := o.Next()
+		if d1 != 100*time.Millisecond {
+			t.Errorf("Expected copy to start at base, got %v", d1)
+		}
+		d2, _ := o.Next()
+		if d2 != 150*time.Millisecond {
+			t.Errorf("Expected overridden max interval 150ms, got %v", d2)
+		}
+
+		// Original options are preserved
+		attempts := 2
+		for {
+			if _, ok := o.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 5 {
+			t.Errorf("Expected max retries to carry over (5), got %d", attempts)
+		}
+
+		// Original is unaffected
+		d3, _ := e.Next()
+		if d3 != 400*time.Millisecond {
+			t.Errorf("Expected original to continue with 400ms, got %v", d3)
+		}
+	})
+
+	t.Run("caller reuses the options slice", func(t *testing.T) {
+		opts := []Option{WithMaxRetries(1)}
+		e := NewExponential(100*time.Millisecond, 2.0, opts...)
+		opts[0] = WithMaxRetries(0)
+
+		o := e.WithOverrides()
+		if _, ok := o.Next(); !ok {
+			t.Error("Expected the copy to keep the options given at creation")
+		}
+	})
+
+	t.Run("all strategies", func(t *testing.T) {
+		strategies := []struct {
+			name     string
+			sequence interface {
+				Sequence
+				WithOverrides(...Option) Sequence
+			}
+		}{
+			{"Constant", NewConstant(100 * time.Millisecond)},
+			{"Exponential", NewExponential(100*time.Millisecond, 2.0)},
+			{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0)},
+			{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0)},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				o := strategy.sequence.WithOverrides(WithMaxRetries(1))
+				if _, ok := o.Next(); !ok {
+					t.Error("First Next() should succeed")
+				}
+				if _, ok := o.Next(); ok {
+					t.Error("Second Next() should fail with overridden maxRetries=1")
+				}
+				if _, ok := strategy.sequence.Next(); !ok {
+					t.Error("Original should not be limited by the override")
+				}
+			})
+		}
+	})
+}
+
+func TestDelayAt(t *testing.T) {
+	strategies := []struct {
+		name     string
+		sequence interface {
+			Sequence
+			DelayAt(int) time.Duration
+		}
+	}{
+		{"Constant", NewConstant(100 * time.Millisecond)},
+		{"Exponential", NewExponential(10*time.Millisecond, 2.0, WithMaxInterval(time.Second))},
+		{"Exponential with min", NewExponential(time.Millisecond, 3.0, WithMinInterval(5*time.Millisecond))},
+		{"Hybrid", NewHybrid(10*time.Millisecond, 10*time.Millisecond, 3, 2.0, WithMaxInterval(time.Second))},
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategy.name, func(t *testing.T) {
+			s := strategy.sequence
+
+			// DelayAt must not advance the sequence
+			for i := 0; i < 10; i++ {
+				s.DelayAt(i)
+			}
+
+			for i := 0; i < 10; i++ {
+				expected := s.DelayAt(i)
+				d, _ := s.Next()
+				if d != expected {
+					t.Errorf("Attempt %d: DelayAt returned %v, Next returned %v", i, expected, d)
+				}
+			}
+		})
+	}
+
+	t.Run("exponential values", func(t *testing.T) {
+		e := NewExponential(10*time.Millisecond, 2.0, WithMaxInterval(time.Second))
+		if d := e.DelayAt(3); d != 80*time.Millisecond {
+			t.Errorf("Expected 80ms, got %v", d)
+		}
+		if d := e.DelayAt(1000); d != time.Second {
+			t.Errorf("Expected delay capped at 1s, got %v", d)
+		}
+		if d := NewExponential(time.Second, 2.0).DelayAt(100); d != time.Duration(math.MaxInt64) {
+			t.Errorf("Expected delay capped at math.MaxInt64, got %v", d)
+		}
+	})
+}
+
 func TestEdgeCases(t *testing.T) {
 	t.Run("very large durations", func(t *testing.T) {
 		// Test with duration close to max
@@ -669,12 +2156,63 @@ func TestEdgeCases(t *testing.T) {
 		}
 	})
 
-	t.Run("zero max retries", func(t *testing.T) {
-		c := NewConstant(100*time.Millisecond, WithMaxRetries(0))
+	t.Run("growth near the cap", func(t *testing.T) {
+		// float64 cannot represent every int64 near math.MaxInt64, so the
+		// growth must neither decrease nor overflow there.
+		e := NewExponential(time.Duration(math.MaxInt64/2+12345), 2.0, WithMaxRetries(4))
+		want := []time.Duration{math.MaxInt64/2 + 12345, math.MaxInt64, math.MaxInt64, math.MaxInt64}
+		for i, w := range want {
+			if d, ok := e.Next(); !ok || d != w {
+				t.Errorf("Call %d: expected (%v, true), got (%v, %v)", i+1, w, d, ok)
+			}
+		}
 
-		_, ok := c.Next()
-		if ok {
-			t.Error("Should fail immediately with maxRetries=0")
+		for _, f := range []float64{math.Nextafter(1, 2), 1.0000001, 1.5, 2.0} {
+			for _, d := range []time.Duration{1<<62 + 1535, 1<<62 + 511, math.MaxInt64 - 1023, math.MaxInt64 - 512, math.MaxInt64} {
+				got := mulDuration(d, f)
+				if got < d {
+					t.Errorf("mulDuration(%d, %v) = %d decreased", d, f, got)
+				}
+			}
+		}
+
+		h := NewHybrid(time.Duration(math.MaxInt64-1), 0, 0, math.Nextafter(1, 2))
+		prev := time.Duration(0)
+		for i := 0; i < 5; i++ {
+			d, _ := h.Next()
+			if d < prev {
+				t.Fatalf("Call %d: delay decreased from %d to %d", i+1, prev, d)
+			}
+			prev = d
+		}
+	})
+
+	t.Run("zero max retries", func(t *testing.T) {
+		// WithMaxRetries(0) disables retries for every strategy
+		strategies := []struct {
+			name     string
+			sequence Sequence
+		}{
+			{"Constant", NewConstant(100*time.Millisecond, WithMaxRetries(0))},
+			{"Exponential", NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(0))},
+			{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0, WithMaxRetries(0))},
+			{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0, WithMaxRetries(0))},
+			{"WeightedRandom", NewWeightedRandom([]WeightedDelay{{Delay: time.Second, Weight: 1}}, WithMaxRetries(0))},
+			{"Deadline", NewDeadline(time.Minute, 5, WithMaxRetries(0))},
+			{"Pacer", NewPacer(time.Second, 100*time.Millisecond, WithMaxRetries(0))},
+			{"Probing", NewProbing(100*time.Millisecond, 2.0, time.Second, 3, 10*time.Millisecond, WithMaxRetries(0))},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				s := strategy.sequence
+				for i := 0; i < 2; i++ {
+					if d, ok := s.Next(); ok || d != 0 {
+						t.Errorf("Expected (0, false) with maxRetries=0, got (%v, %v)", d, ok)
+					}
+					s.Reset()
+				}
+			})
 		}
 	})
 
@@ -758,3 +2296,373 @@ func TestRandBetween(t *testing.T) {
 		}
 	})
 }
+
+func TestDegenerateDurations(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	edges := []time.Duration{math.MinInt64, -1, 0, 1, 2, math.MaxInt64}
+
+	t.Run("randInt64N", func(t *testing.T) {
+		for _, n := range []int64{math.MinInt64, -1, 0} {
+			if v, ok := randInt64N(r, n); ok || v != 0 {
+				t.Errorf("randInt64N(%d): expected (0, false), got (%d, %v)", n, v, ok)
+			}
+		}
+		if v, ok := randInt64N(r, 1); !ok || v != 0 {
+			t.Errorf("randInt64N(1): expected (0, true), got (%d, %v)", v, ok)
+		}
+	})
+
+	for _, jitter := range builtinJitters {
+		t.Run(jitterName(jitter), func(t *testing.T) {
+			for _, d := range edges {
+				// Negative delays only must not panic
+				if got := jitter.Apply(d, r); d >= 0 && (got < 0 || got > d) {
+					t.Errorf("Apply(%d): expected a value in [0, %d], got %d", d, d, got)
+				}
+			}
+		})
+	}
+
+	t.Run("randBetween", func(t *testing.T) {
+		for _, low := range edges {
+			for _, high := range edges {
+				got := randBetween(r, low, high)
+				if high > low && (got < low || got > high) {
+					t.Errorf("randBetween(%d, %d): got %d", low, high, got)
+				}
+			}
+		}
+	})
+
+	t.Run("strategies", func(t *testing.T) {
+		strategies := map[string]Sequence{
+			"Exponential":  NewExponential(1, 2.0, WithJitter()),
+			"Decorrelated": NewDecorrelated(1, 1.0),
+			"Hybrid":       NewHybrid(1, 0, 1, 2.0, WithJitterStrategy(FullJitterFromZero{})),
+			"Pacer":        NewPacer(1, 1),
+		}
+		for name, s := range strategies {
+			for i := 0; i < 10; i++ {
+				if d, ok := s.Next(); !ok || d < 0 {
+					t.Errorf("%s: call %d: expected a non-negative delay, got (%v, %v)", name, i+1, d, ok)
+				}
+			}
+		}
+	})
+}
+
+// panicSource is a rand.Source that always panics.
+type panicSource struct{}
+
+func (panicSource) Uint64() uint64 {
+	panic("broken source")
+}
+
+func TestMisbehavingRand(t *testing.T) {
+	r := rand.New(panicSource{})
+	d := 100 * time.Millisecond
+
+	jitters := map[string]Jitter{
+		"FullJitter":         FullJitter{},
+		"EqualJitter":        EqualJitter{},
+		"BetaJitter":         BetaJitter{Alpha: 2, Beta: 2},
+		"FullJitterFromZero": FullJitterFromZero{},
+		"ProportionalJitter": ProportionalJitter{Fraction: 0.2},
+	}
+	for name, jitter := range jitters {
+		t.Run(name, func(t *testing.T) {
+			if got := jitter.Apply(d, r); got != d {
+				t.Errorf("Expected fallback to the un-jittered %v, got %v", d, got)
+			}
+			if got := jitter.Apply(d, nil); got != d {
+				t.Errorf("Expected fallback to the un-jittered %v with nil rand, got %v", d, got)
+			}
+		})
+	}
+
+	t.Run("ElapsedProportionalJitter", func(t *testing.T) {
+		jitter := ElapsedProportionalJitter{Fraction: 0.5}
+		if got := jitter.ApplyWithElapsed(d, time.Minute, r); got != d {
+			t.Errorf("Expected fallback to the un-jittered %v, got %v", d, got)
+		}
+		if got := jitter.ApplyWithElapsed(d, time.Minute, nil); got != d {
+			t.Errorf("Expected fallback to the un-jittered %v with nil rand, got %v", d, got)
+		}
+	})
+
+	t.Run("randBetween", func(t *testing.T) {
+		if got := randBetween(r, 10*time.Millisecond, d); got != d {
+			t.Errorf("Expected fallback to high %v, got %v", d, got)
+		}
+		if got := randBetween(rand.New(rand.NewPCG(1, 2)), 0, time.Duration(math.MaxInt64)); got < 0 {
+			t.Errorf("Expected non-negative result for the full range, got %v", got)
+		}
+	})
+
+	t.Run("RandFloat", func(t *testing.T) {
+		if _, ok := RandFloat(r); ok {
+			t.Error("Expected ok=false for a panicking source")
+		}
+		if _, ok := RandFloat(nil); ok {
+			t.Error("Expected ok=false for a nil rand")
+		}
+
+		good := rand.New(rand.NewPCG(1, 2))
+		for i := 0; i < 1000; i++ {
+			if f, ok := RandFloat(good); !ok || f < 0 || f >= 1 {
+				t.Fatalf("Expected a value in [0, 1), got (%v, %v)", f, ok)
+			}
+		}
+	})
+
+	t.Run("strategies keep working", func(t *testing.T) {
+		w := NewWeightedRandom([]WeightedDelay{{Delay: 0, Weight: 0}, {Delay: d, Weight: 1}, {Delay: 2 * d, Weight: 1}},
+			WithRandSource(panicSource{}))
+		if got, ok := w.Next(); !ok || got != d {
+			t.Errorf("Expected (%v, true), got (%v, %v)", d, got, ok)
+		}
+
+		e := NewExponential(d, 2.0,
+			WithRandSource(panicSource{}),
+			WithJitterStrategy(FullJitter{}))
+		if got, ok := e.Next(); !ok || got != d {
+			t.Errorf("Expected (%v, true), got (%v, %v)", d, got, ok)
+		}
+
+		dcr := NewDecorrelated(d, 3.0, WithRandSource(panicSource{}))
+		for i := 0; i < 3; i++ {
+			if _, ok := dcr.Next(); !ok {
+				t.Errorf("Next() call %d should succeed", i+1)
+			}
+		}
+	})
+}
+
+// elapsedLog is an ElapsedAwareJitter that records the elapsed times it
+// receives and leaves delays unchanged.
+type elapsedLog []time.Duration
+
+func (l *elapsedLog) Apply(d time.Duration, _ *rand.Rand) time.Duration {
+	return d
+}
+
+func (l *elapsedLog) ApplyWithElapsed(d, elapsed time.Duration, _ *rand.Rand) time.Duration {
+	*l = append(*l, elapsed)
+	return d
+}
+
+func TestElapsedAwareJitter(t *testing.T) {
+	var log elapsedLog
+	e := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(&log), WithElapsedOffset(time.Second))
+	for i := 0; i < 3; i++ {
+		e.Next()
+	}
+
+	want := []time.Duration{time.Second, 1100 * time.Millisecond, 1300 * time.Millisecond}
+	if !slices.Equal(log, want) {
+		t.Errorf("Expected elapsed times %v, got %v", want, log)
+	}
+
+	// Plain jitters keep working through Apply
+	e = NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(inflateJitter{}))
+	if d, _ := e.Next(); d != 300*time.Millisecond {
+		t.Errorf("Expected plain Apply to be used, got %v", d)
+	}
+}
+
+// inflateJitter is a Jitter that triples every delay.
+type inflateJitter struct{}
+
+func (inflateJitter) Apply(d time.Duration, _ *rand.Rand) time.Duration {
+	return 3 * d
+}
+
+func TestMaxIntervalAfterJitter(t *testing.T) {
+	maxInterval := 500 * time.Millisecond
+	opts := []Option{WithMaxInterval(maxInterval), WithJitterStrategy(inflateJitter{})}
+
+	strategies := []struct {
+		name     string
+		sequence Sequence
+	}{
+		{"Exponential", NewExponential(100*time.Millisecond, 2.0, opts...)},
+		{"Decorrelated", NewDecorrelated(400*time.Millisecond, 3.0, opts...)},
+		{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 2, 2.0, opts...)},
+		{"WeightedRandom", NewWeightedRandom([]WeightedDelay{{Delay: 400 * time.Millisecond, Weight: 1}}, opts...)},
+		{"Deadline", NewDeadline(5*time.Second, 5, opts...)},
+		{"Pacer", NewPacer(400*time.Millisecond, 100*time.Millisecond, opts...)},
+		{"Probing", NewProbing(100*time.Millisecond, 2.0, 400*time.Millisecond, 2, 50*time.Millisecond, opts...)},
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategy.name, func(t *testing.T) {
+			capped := false
+			for i := 0; i < 10; i++ {
+				d, ok := strategy.sequence.Next()
+				if !ok {
+					break
+				}
+				if d > maxInterval {
+					t.Errorf("Call %d: %v exceeds max interval %v", i+1, d, maxInterval)
+				}
+				capped = capped || d == maxInterval
+			}
+			if !capped {
+				t.Error("Expected the inflated delays to hit the cap")
+			}
+		})
+	}
+}
+
+func TestNextContext(t *testing.T) {
+	choices := []WeightedDelay{{Delay: time.Second, Weight: 1}}
+	ch := make(chan time.Duration, 2)
+	ch <- time.Second
+	ch <- time.Second
+	once := WithMaxRetries(1)
+	strategies := map[string]ContextSequence{
+		"Constant":        NewConstant(time.Second, once),
+		"Exponential":     NewExponential(time.Second, 2.0, once),
+		"Decorrelated":    NewDecorrelated(time.Second, 3.0, once),
+		"Hybrid":          NewHybrid(time.Second, time.Second, 3, 2.0, once),
+		"WeightedRandom":  NewWeightedRandom(choices, once),
+		"Deadline":        NewDeadline(time.Minute, 3, once),
+		"Pacer":           NewPacer(time.Second, 0, once),
+		"Probing":         NewProbing(time.Second, 2.0, time.Minute, 4, time.Second, once),
+		"ChannelSequence": NewChannelSequence(ch, once),
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, s := range strategies {
+		t.Run(name, func(t *testing.T) {
+			if d, ok := s.NextContext(cancelled); ok || d != 0 {
+				t.Errorf("Expected (0, false) with a cancelled context, got (%v, %v)", d, ok)
+			}
+
+			// The only attempt was not consumed
+			if _, ok := s.NextContext(context.Background()); !ok {
+				t.Error("Expected the attempt to be left after cancellation")
+			}
+			if _, ok := s.NextContext(context.Background()); ok {
+				t.Error("Expected exhaustion after the only attempt")
+			}
+		})
+	}
+}
+
+func TestAttemptsAndElapsed(t *testing.T) {
+	choices := []WeightedDelay{{Delay: time.Second, Weight: 1}}
+	ch := make(chan time.Duration, 3)
+	for i := 0; i < 3; i++ {
+		ch <- time.Second
+	}
+	strategies := map[string]interface {
+		Sequence
+		Attempts() int
+		Elapsed() time.Duration
+	}{
+		"Constant":        NewConstant(time.Second),
+		"Exponential":     NewExponential(time.Second, 2.0),
+		"Decorrelated":    NewDecorrelated(time.Second, 3.0),
+		"Hybrid":          NewHybrid(time.Second, time.Second, 3, 2.0),
+		"WeightedRandom":  NewWeightedRandom(choices),
+		"Deadline":        NewDeadline(time.Minute, 5),
+		"Pacer":           NewPacer(time.Second, 0),
+		"Probing":         NewProbing(time.Second, 2.0, time.Minute, 4, time.Second),
+		"ChannelSequence": NewChannelSequence(ch),
+	}
+
+	for name, s := range strategies {
+		t.Run(name, func(t *testing.T) {
+			if s.Attempts() != 0 || s.Elapsed() != 0 {
+				t.Fatalf("Expected (0, 0) initially, got (%d, %v)", s.Attempts(), s.Elapsed())
+			}
+
+			var sum time.Duration
+			for i := 1; i <= 3; i++ {
+				d, _ := s.Next()
+				sum += d
+				if got := s.Attempts(); got != i {
+					t.Errorf("Call %d: expected %d attempts, got %d", i, i, got)
+				}
+				if got := s.Elapsed(); got != sum {
+					t.Errorf("Call %d: expected elapsed %v, got %v", i, sum, got)
+				}
+			}
+
+			s.Reset()
+			if s.Attempts() != 0 || s.Elapsed() != 0 {
+				t.Errorf("Expected (0, 0) after Reset, got (%d, %v)", s.Attempts(), s.Elapsed())
+			}
+		})
+	}
+
+	e := NewExponential(time.Second, 2.0, WithElapsedOffset(5*time.Second))
+	e.Next()
+	if got := e.Elapsed(); got != 6*time.Second {
+		t.Errorf("Expected the elapsed offset to be included, got %v", got)
+	}
+}
+
+func TestRemainingRetries(t *testing.T) {
+	t.Run("finite", func(t *testing.T) {
+		e := NewExponential(time.Second, 2.0, WithMaxRetries(3))
+		for want := 3; want >= 0; want-- {
+			if got := e.RemainingRetries(); got != want {
+				t.Errorf("Expected %d retries left, got %d", want, got)
+			}
+			e.Next()
+		}
+
+		// Never negative once the limit is reached
+		e.Next()
+		if got := e.RemainingRetries(); got != 0 {
+			t.Errorf("Expected 0 retries left after exhaustion, got %d", got)
+		}
+		e.Reset()
+		if got := e.RemainingRetries(); got != 3 {
+			t.Errorf("Expected 3 retries left after Reset, got %d", got)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		c := NewConstant(time.Second)
+		for i := 0; i < 5; i++ {
+			if got := c.RemainingRetries(); got != -1 {
+				t.Errorf("Expected -1 for unlimited retries, got %d", got)
+			}
+			c.Next()
+		}
+	})
+
+	t.Run("all strategies", func(t *testing.T) {
+		choices := []WeightedDelay{{Delay: time.Second, Weight: 1}}
+		ch := make(chan time.Duration, 1)
+		ch <- time.Second
+		twice := WithMaxRetries(2)
+		strategies := map[string]interface {
+			Sequence
+			RemainingRetries() int
+		}{
+			"Constant":        NewConstant(time.Second, twice),
+			"Exponential":     NewExponential(time.Second, 2.0, twice),
+			"Decorrelated":    NewDecorrelated(time.Second, 3.0, twice),
+			"Hybrid":          NewHybrid(time.Second, time.Second, 3, 2.0, twice),
+			"WeightedRandom":  NewWeightedRandom(choices, twice),
+			"Deadline":        NewDeadline(time.Minute, 5, twice),
+			"Pacer":           NewPacer(time.Second, 0, twice),
+			"Probing":         NewProbing(time.Second, 2.0, time.Minute, 4, time.Second, twice),
+			"ChannelSequence": NewChannelSequence(ch, twice),
+		}
+		for name, s := range strategies {
+			t.Run(name, func(t *testing.T) {
+				s.Next()
+				if got := s.RemainingRetries(); got != 1 {
+					t.Errorf("Expected 1 retry left, got %d", got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Package backofftest provides utilities for testing code that uses the
+// backoff package.
+package backofftest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SleepCall records a single call to RecordingSleeper.Sleep.
+type SleepCall struct {
+	Ctx      context.Context // context passed to Sleep
+	Duration time.Duration   // duration Sleep was asked to wait
+}
+
+// RecordingSleeper implements backoff.Sleeper without waiting. It records
+// every call, so tests can assert the exact retry schedule their code
+// produced. It is safe for concurrent use.
+//
+// Example:
+//
+//	s := &backofftest.RecordingSleeper{}
+//	err := backoff.RetryWithContext(ctx, b, op, backoff.WithSleeper(s))
+//	if got := s.Durations(); !slices.Equal(got, want) {
+//		t.Errorf("unexpected schedule %v", got)
+//	}
+type RecordingSleeper struct {
+	mu    sync.Mutex
+	calls []SleepCall
+}
+
+// Sleep records the call and returns immediately with the context error,
+// if any.
+func (s *RecordingSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	s.mu.Lock()
+	s.calls = append(s.calls, SleepCall{Ctx: ctx, Duration: d})
+	s.mu.Unlock()
+
+	return ctx.Err()
+}
+
+// Calls returns a copy of all recorded calls in the order they were made.
+func (s *RecordingSleeper) Calls() []SleepCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]SleepCall, len(s.calls))
+	copy(calls, s.calls)
+	return calls
+}
+
+// Durations returns the recorded durations in the order they were
+// requested.
+func (s *RecordingSleeper) Durations() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	durations := make([]time.Duration, len(s.calls))
+	for i, c := range s.calls {
+		durations[i] = c.Duration
+	}
+	return durations
+}
+
+// Total returns the sum of all recorded durations, i.e. the time the code
+// under test would have spent sleeping.
+func (s *RecordingSleeper) Total() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total time.Duration
+	for _, c := range s.calls {
+		total += c.Duration
+	}
+	return total
+}
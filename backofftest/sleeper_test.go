@@ -0,0 +1,52 @@
+package backofftest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alexjoedt/backoff"
+)
+
+var _ backoff.Sleeper = (*RecordingSleeper)(nil)
+
+func TestRecordingSleeper(t *testing.T) {
+	t.Run("records the retry schedule", func(t *testing.T) {
+		s := &RecordingSleeper{}
+		b := backoff.NewExponential(100*time.Millisecond, 2.0, backoff.WithMaxRetries(3))
+
+		errFail := errors.New("fail")
+		err := backoff.RetryWithContext(context.Background(), b, func(context.Context) error {
+			return errFail
+		}, backoff.WithSleeper(s))
+		if !errors.Is(err, errFail) {
+			t.Fatalf("Expected %v, got %v", errFail, err)
+		}
+
+		want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+		if got := s.Durations(); !slices.Equal(got, want) {
+			t.Errorf("Expected durations %v, got %v", want, got)
+		}
+		if got := s.Total(); got != 700*time.Millisecond {
+			t.Errorf("Expected total %v, got %v", 700*time.Millisecond, got)
+		}
+		if calls := s.Calls(); len(calls) != 3 || calls[0].Ctx == nil {
+			t.Errorf("Expected 3 calls with their context, got %v", calls)
+		}
+	})
+
+	t.Run("returns the context error", func(t *testing.T) {
+		s := &RecordingSleeper{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := s.Sleep(ctx, time.Second); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if got := s.Durations(); len(got) != 1 {
+			t.Errorf("Expected the call to be recorded, got %v", got)
+		}
+	})
+}
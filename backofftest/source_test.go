@@ -0,0 +1,110 @@
+package backofftest
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/alexjoedt/backoff"
+)
+
+var _ rand.Source = (*CountingSource)(nil)
+
+func TestCountingSource(t *testing.T) {
+	src := NewCountingSource(rand.NewPCG(1, 2))
+	want := rand.NewPCG(1, 2)
+	for i := 0; i < 3; i++ {
+		if got, w := src.Uint64(), want.Uint64(); got != w {
+			t.Fatalf("Draw %d: expected the wrapped value %d, got %d", i+1, w, got)
+		}
+	}
+	if n := src.Count(); n != 3 {
+		t.Errorf("Expected 3 draws, got %d", n)
+	}
+	src.ResetCount()
+	if n := src.Count(); n != 0 {
+		t.Errorf("Expected 0 after ResetCount, got %d", n)
+	}
+}
+
+// TestDrawContract pins the number of random values each strategy draws
+// per call to Next, as documented on backoff.WithRandSource.
+func TestDrawContract(t *testing.T) {
+	const d = 100 * time.Millisecond
+	choices := []backoff.WeightedDelay{{Delay: d, Weight: 1}, {Delay: 2 * d, Weight: 1}}
+	jitter := backoff.WithJitterStrategy
+
+	tests := []struct {
+		name  string
+		new   func(backoff.Option) backoff.Sequence
+		draws int
+	}{
+		{"Constant", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewConstant(d, o, jitter(backoff.FullJitter{}))
+		}, 0},
+		{"Exponential", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o)
+		}, 0},
+		{"Exponential/full", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.FullJitter{}))
+		}, 1},
+		{"Exponential/full-from-zero", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.FullJitterFromZero{}))
+		}, 1},
+		{"Exponential/equal", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.EqualJitter{}))
+		}, 1},
+		{"Exponential/proportional", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.ProportionalJitter{Fraction: 0.2}))
+		}, 1},
+		{"Exponential/slotted", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.SlottedJitter{Index: 1, Total: 4}))
+		}, 0},
+		{"Exponential/deterministic", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, backoff.WithDeterministicJitter(0.5))
+		}, 0},
+		{"Exponential/elapsed-proportional", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewExponential(d, 2.0, o, jitter(backoff.ElapsedProportionalJitter{Fraction: 0.5}),
+				backoff.WithElapsedOffset(time.Second))
+		}, 1},
+		{"Decorrelated", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewDecorrelated(d, 3.0, o)
+		}, 1},
+		{"Decorrelated/full", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewDecorrelated(d, 3.0, o, jitter(backoff.FullJitter{}))
+		}, 2},
+		{"Hybrid/equal", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewHybrid(d, d, 2, 2.0, o, jitter(backoff.EqualJitter{}))
+		}, 1},
+		{"WeightedRandom", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewWeightedRandom(choices, o)
+		}, 1},
+		{"Deadline/full", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewDeadline(time.Minute, 5, o, jitter(backoff.FullJitter{}))
+		}, 1},
+		{"Pacer", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewPacer(time.Second, d, o)
+		}, 1},
+		{"Probing/full", func(o backoff.Option) backoff.Sequence {
+			return backoff.NewProbing(d, 2.0, time.Second, 2, d, o, jitter(backoff.FullJitter{}))
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewCountingSource(rand.NewPCG(1, 2))
+			s := tt.new(backoff.WithRandSource(src))
+			src.ResetCount() // ignore draws at construction
+
+			for i := 0; i < 5; i++ {
+				if _, ok := s.Next(); !ok {
+					t.Fatalf("Call %d: unexpected exhaustion", i+1)
+				}
+				if n := src.Count(); n != tt.draws {
+					t.Errorf("Call %d: expected %d draws, got %d", i+1, tt.draws, n)
+				}
+				src.ResetCount()
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package backoff
 
 import (
+	crand "crypto/rand"
 	"math/rand/v2"
+	"sync"
 	"testing"
 	"time"
 )
@@ -48,6 +50,50 @@ func BenchmarkDecorrelated(b *testing.B) {
 	}
 }
 
+// BenchmarkDecorrelatedWithJitter measures decorrelated backoff with
+// jitter, which draws from the random source twice per call
+func BenchmarkDecorrelatedWithJitter(b *testing.B) {
+	decorrelated := NewDecorrelated(100*time.Millisecond, 3.0,
+		WithJitter(),
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = decorrelated.Next()
+	}
+}
+
+// BenchmarkDecorrelatedRandSource compares the cost of the random source
+// on the decorrelated path: the fixed PCG against a ChaCha8 source seeded
+// from crypto/rand
+func BenchmarkDecorrelatedRandSource(b *testing.B) {
+	var seed [32]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		b.Fatal(err)
+	}
+
+	sources := map[string]func() rand.Source{
+		"PCG":     func() rand.Source { return rand.NewPCG(42, 1024) },
+		"ChaCha8": func() rand.Source { return rand.NewChaCha8(seed) },
+	}
+
+	for name, source := range sources {
+		b.Run(name, func(b *testing.B) {
+			decorrelated := NewDecorrelated(100*time.Millisecond, 3.0,
+				WithJitter(),
+				WithRandSource(source()),
+			)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = decorrelated.Next()
+			}
+		})
+	}
+}
+
 // BenchmarkJitterStrategies compares different jitter strategies
 func BenchmarkJitterStrategies(b *testing.B) {
 	duration := 100 * time.Millisecond
@@ -111,3 +157,45 @@ func BenchmarkMemoryAllocation(b *testing.B) {
 		_, _ = exponential.Next()
 	}
 }
+
+// lockedSource is a rand.Source safe for concurrent use, serializing all
+// draws on a single mutex.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+// BenchmarkFastRand compares strategies drawing from a shared synchronized
+// source with WithFastRand under concurrency.
+func BenchmarkFastRand(b *testing.B) {
+	b.Run("LockedSource", func(b *testing.B) {
+		src := &lockedSource{src: rand.NewPCG(42, 1024)}
+		b.RunParallel(func(pb *testing.PB) {
+			exp := NewExponential(100*time.Millisecond, 2.0,
+				WithMaxInterval(5*time.Second),
+				WithJitter(),
+				WithRandSource(src))
+			for pb.Next() {
+				_, _ = exp.Next()
+			}
+		})
+	})
+
+	b.Run("FastRand", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			exp := NewExponential(100*time.Millisecond, 2.0,
+				WithMaxInterval(5*time.Second),
+				WithJitter(),
+				WithFastRand())
+			for pb.Next() {
+				_, _ = exp.Next()
+			}
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package backoff
+
+import "sync/atomic"
+
+// SharedBudget is a retry budget shared by multiple strategies, e.g. all
+// instances used by a worker pool. Every successful call to Next() on a
+// strategy configured with WithSharedBudget consumes one retry from the
+// budget; once it is used up, Next() returns (0, false) on all of them.
+//
+// SharedBudget is safe for concurrent use. The strategies themselves are
+// not, so each goroutine should still use its own instance.
+type SharedBudget struct {
+	remaining atomic.Int64
+}
+
+// NewSharedBudget creates a new shared budget allowing n retries in total.
+//
+// Example:
+//
+//	budget := NewSharedBudget(100)
+//	for i := 0; i < workers; i++ {
+//		go func() {
+//			b := NewExponential(100*time.Millisecond, 2.0,
+//				WithSharedBudget(budget))
+//			// use b in this goroutine...
+//		}()
+//	}
+func NewSharedBudget(n int) *SharedBudget {
+	b := &SharedBudget{}
+	b.remaining.Store(int64(max(n, 0)))
+	return b
+}
+
+// Remaining returns the number of retries left in the budget.
+func (b *SharedBudget) Remaining() int {
+	return int(b.remaining.Load())
+}
+
+// take consumes one retry from the budget. Returns false if the budget
+// is used up.
+func (b *SharedBudget) take() bool {
+	for {
+		n := b.remaining.Load()
+		if n <= 0 {
+			return false
+		}
+		if b.remaining.CompareAndSwap(n, n-1) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package backoff
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedBudget(t *testing.T) {
+	t.Run("shared across strategies", func(t *testing.T) {
+		budget := NewSharedBudget(3)
+		a := NewConstant(time.Millisecond, WithSharedBudget(budget))
+		b := NewExponential(time.Millisecond, 2.0, WithSharedBudget(budget))
+
+		if _, ok := a.Next(); !ok {
+			t.Fatal("First retry should succeed")
+		}
+		if _, ok := b.Next(); !ok {
+			t.Fatal("Second retry should succeed")
+		}
+		if _, ok := a.Next(); !ok {
+			t.Fatal("Third retry should succeed")
+		}
+		if budget.Remaining() != 0 {
+			t.Errorf("Expected budget to be used up, %d remaining", budget.Remaining())
+		}
+		if _, ok := b.Next(); ok {
+			t.Error("Expected Next() to fail once the shared budget is used up")
+		}
+
+		// Reset does not refill the shared budget
+		a.Reset()
+		if _, ok := a.Next(); ok {
+			t.Error("Expected Next() to fail after Reset() with a used up shared budget")
+		}
+	})
+
+	t.Run("local limits do not consume budget", func(t *testing.T) {
+		budget := NewSharedBudget(5)
+		c := NewConstant(time.Millisecond, WithMaxRetries(1), WithSharedBudget(budget))
+		c.Next()
+		c.Next()
+		if budget.Remaining() != 4 {
+			t.Errorf("Expected 4 retries remaining, got %d", budget.Remaining())
+		}
+	})
+
+	t.Run("negative budget", func(t *testing.T) {
+		budget := NewSharedBudget(-1)
+		if _, ok := NewConstant(time.Millisecond, WithSharedBudget(budget)).Next(); ok {
+			t.Error("Expected Next() to fail with an empty budget")
+		}
+	})
+
+	t.Run("concurrent workers", func(t *testing.T) {
+		const total = 1000
+		budget := NewSharedBudget(total)
+
+		var granted atomic.Int64
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b := NewExponential(time.Millisecond, 2.0,
+					WithMaxInterval(time.Second),
+					WithSharedBudget(budget))
+				for {
+					if _, ok := b.Next(); !ok {
+						return
+					}
+					granted.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if granted.Load() != total {
+			t.Errorf("Expected exactly %d retries across workers, got %d", total, granted.Load())
+		}
+	})
+}
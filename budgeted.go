@@ -0,0 +1,104 @@
+package backoff
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// NewBudgetedExponential creates an exponential backoff whose factor is
+// chosen so that exactly attempts delays, starting at base, add up to
+// budget. This is a top-down alternative to cutting retries short with
+// WithMaxElapsed: the growth is tuned to the constraints instead.
+//
+// The sum of the delays is the geometric series
+//
+//	base * (factor^attempts - 1) / (factor - 1)
+//
+// which is strictly increasing in factor. Solving it for factor has no
+// closed form, so the factor is found by bisection. Because delays are
+// whole nanoseconds, their sum may fall short of budget by a few
+// nanoseconds per attempt, but never exceeds it.
+//
+// The returned strategy is configured with WithMaxRetries(attempts) before
+// opts. Jitter and bounds given in opts apply on top and change the sum.
+//
+// Returns an error if attempts < 1, base <= 0, or budget is not larger
+// than attempts * base, since no factor > 1 could fit the budget then. The
+// same error is returned if opts such as WithMinInterval or
+// WithAdditiveBase push the sum over budget for every factor.
+//
+// Example:
+//
+//	// 8 attempts starting at 100ms, adding up to 1 minute
+//	b, err := NewBudgetedExponential(100*time.Millisecond, 8, time.Minute)
+func NewBudgetedExponential(base time.Duration, attempts int, budget time.Duration, opts ...Option) (*Exponential, error) {
+	switch {
+	case attempts < 1:
+		return nil, errors.New("backoff: budgeted exponential needs at least one attempt")
+	case base <= 0:
+		return nil, errors.New("backoff: budgeted exponential needs a positive base")
+	case float64(budget) <= float64(base)*float64(attempts):
+		return nil, errors.New("backoff: budget too small for the attempts to grow")
+	}
+
+	factor := 2.0
+	if attempts > 1 {
+		factor = solveFactor(float64(base), attempts, float64(budget))
+	}
+
+	all := make([]Option, 0, len(opts)+1)
+	all = append(all, WithMaxRetries(attempts))
+	all = append(all, opts...)
+
+	// Bounds, additive offsets, scaling or float rounding can push the sum
+	// over the budget. Check that the slowest growth fits at all, then lower
+	// the factor until it does.
+	sum := func(f float64) time.Duration {
+		return NewExponential(base, f, all...).CumulativeDelay(attempts)
+	}
+	lowest := math.Nextafter(1, 2)
+	if sum(lowest) > budget {
+		return nil, errors.New("backoff: budget too small for the configured options")
+	}
+	if sum(factor) > budget {
+		factor = fitFactor(sum, lowest, factor, budget)
+	}
+	return NewExponential(base, factor, all...), nil
+}
+
+// fitFactor returns the largest factor in [low, high] found by bisection
+// for which sum stays within budget. sum(low) must not exceed budget.
+func fitFactor(sum func(float64) time.Duration, low, high float64, budget time.Duration) float64 {
+	for i := 0; i < 100 && high-low > 1e-12*high; i++ {
+		mid := low + (high-low)/2
+		if sum(mid) > budget {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return low
+}
+
+// solveFactor returns the factor > 1 for which the geometric series of n
+// terms starting at base sums up to budget.
+func solveFactor(base float64, n int, budget float64) float64 {
+	sum := func(f float64) float64 {
+		return base * (math.Pow(f, float64(n)) - 1) / (f - 1)
+	}
+
+	low, high := 1.0, 2.0
+	for sum(high) < budget {
+		low, high = high, high*2
+	}
+	for i := 0; i < 200 && high-low > 1e-15*high; i++ {
+		mid := low + (high-low)/2
+		if sum(mid) < budget {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return max(low, math.Nextafter(1, 2))
+}
@@ -0,0 +1,89 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewBudgetedExponential(t *testing.T) {
+	t.Run("fits the budget", func(t *testing.T) {
+		tests := []struct {
+			base     time.Duration
+			attempts int
+			budget   time.Duration
+		}{
+			{100 * time.Millisecond, 8, time.Minute},
+			{time.Second, 3, 7 * time.Second}, // factor 2
+			{10 * time.Millisecond, 20, 500 * time.Millisecond},
+			{time.Millisecond, 1, time.Second},
+		}
+
+		for _, tt := range tests {
+			e, err := NewBudgetedExponential(tt.base, tt.attempts, tt.budget)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			attempts := 0
+			var total time.Duration
+			for {
+				d, ok := e.Next()
+				if !ok {
+					break
+				}
+				attempts++
+				total += d
+			}
+
+			if attempts != tt.attempts {
+				t.Errorf("%v over %d: expected %d attempts, got %d", tt.budget, tt.attempts, tt.attempts, attempts)
+			}
+			if tt.attempts > 1 && (total > tt.budget || total < tt.budget-time.Duration(tt.attempts)*time.Microsecond) {
+				t.Errorf("%v over %d: expected the delays to add up to the budget, got %v", tt.budget, tt.attempts, total)
+			}
+		}
+	})
+
+	t.Run("solves the factor", func(t *testing.T) {
+		e, err := NewBudgetedExponential(time.Second, 3, 7*time.Second)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if e.factor < 1.999999 || e.factor > 2.000001 {
+			t.Errorf("Expected factor 2, got %v", e.factor)
+		}
+	})
+
+	t.Run("infeasible inputs", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			base     time.Duration
+			attempts int
+			budget   time.Duration
+			opts     []Option
+		}{
+			{"no attempts", time.Second, 0, time.Minute, nil},
+			{"zero base", 0, 3, time.Minute, nil},
+			{"budget too small", time.Second, 3, 3 * time.Second, nil},
+			{"min interval over budget", 100 * time.Millisecond, 8, time.Minute, []Option{WithMinInterval(10 * time.Second)}},
+			{"scaled over budget", 100 * time.Millisecond, 8, time.Minute, []Option{WithScale(func() float64 { return 1000 })}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if e, err := NewBudgetedExponential(tt.base, tt.attempts, tt.budget, tt.opts...); err == nil {
+					t.Errorf("Expected an error, got %+v", e.Describe())
+				}
+			})
+		}
+	})
+	t.Run("options shrink the factor", func(t *testing.T) {
+		e, err := NewBudgetedExponential(100*time.Millisecond, 8, time.Minute, WithAdditiveBase(time.Second))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if total := e.CumulativeDelay(8); total > time.Minute || total < time.Minute-time.Millisecond {
+			t.Errorf("Expected the delays to add up to the budget, got %v", total)
+		}
+	})
+}
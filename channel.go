@@ -0,0 +1,195 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// ChannelSequence implements a backoff strategy that reads its delays from
+// a channel. This inverts control: an external source, such as a
+// controller or a recorded schedule being replayed, drives the timing.
+//
+// It has no DelayAt, JitterSpread or DistinctDelays methods: its delays
+// are not known before they are received, and sampling them would consume
+// them from the channel.
+type ChannelSequence struct {
+	options *options
+	ch      <-chan time.Duration // source of delays
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewChannelSequence creates a new backoff strategy reading its delays
+// from ch. By default Next() blocks until a delay is received; with
+// WithChannelFallback it returns the fallback delay instead of waiting.
+// Once ch is closed, Next() returns (0, false).
+//
+// Delays are returned as received, subject to runtime scaling only; jitter
+// and min/max bounds are not applied. Retry, elapsed and shared budget
+// limits apply on top, and are checked before a delay is read, except for
+// the elapsed limit, which discards the delay that would exceed it.
+// WithRandomStart is ignored, as skipping would consume delays.
+//
+// Example:
+//
+//	ch := make(chan time.Duration, len(recorded))
+//	for _, d := range recorded {
+//		ch <- d
+//	}
+//	close(ch)
+//	replay := NewChannelSequence(ch, WithMaxRetries(10))
+func NewChannelSequence(ch <-chan time.Duration, opts ...Option) *ChannelSequence {
+	o := applyOptions(opts)
+
+	return &ChannelSequence{
+		options: o,
+		ch:      ch,
+		elapsed: o.elapsedOffset,
+	}
+}
+
+// Next returns the next delay read from the channel.
+//
+// Returns:
+//   - time.Duration: The received delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+//     or the channel is closed
+func (c *ChannelSequence) Next() (time.Duration, bool) {
+	return c.next(context.Background())
+}
+
+// next implements Next and NextContext, giving up on the channel when ctx
+// is done.
+func (c *ChannelSequence) next(ctx context.Context) (time.Duration, bool) {
+	if c.options.outOfRetries(c.retries) {
+		c.last, c.hasLast = 0, false
+		return 0, false
+	}
+
+	d, ok := c.receive(ctx)
+	if !ok {
+		c.last, c.hasLast = 0, false
+		return 0, false
+	}
+
+	d = c.options.scaled(max(d, 0))
+	if c.options.maxElapsed > 0 && !c.options.free(c.retries) && c.options.spent(c.elapsed)+d >= c.options.maxElapsed {
+		c.last, c.hasLast = 0, false
+		return 0, false
+	}
+
+	if !c.options.acquire() {
+		c.last, c.hasLast = 0, false
+		return 0, false
+	}
+
+	d = c.options.finish(d)
+	c.elapsed += c.options.charge(c.retries, d)
+	c.retries++
+	c.last, c.hasLast = d, true
+	return d, true
+}
+
+// NextContext is like Next, but also stops waiting for the channel when
+// ctx is done. It then returns (0, false) without consuming a delay or
+// counting a retry.
+func (c *ChannelSequence) NextContext(ctx context.Context) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+	return c.next(ctx)
+}
+
+// receive reads the next delay from the channel, returning the fallback
+// instead of blocking if one is configured. Returns false if ctx is done
+// while waiting.
+func (c *ChannelSequence) receive(ctx context.Context) (time.Duration, bool) {
+	if !c.options.hasFallback {
+		select {
+		case d, ok := <-c.ch:
+			return d, ok
+		case <-ctx.Done():
+			return 0, false
+		}
+	}
+
+	select {
+	case d, ok := <-c.ch:
+		return d, ok
+	default:
+		return c.options.fallback, true
+	}
+}
+
+// Reset resets the retry count, the delay reported by Current and the
+// elapsed time. The channel cannot be rewound; the next call to Next()
+// reads the next delay sent on it.
+func (c *ChannelSequence) Reset() {
+	if !c.options.allowReset() {
+		return
+	}
+	c.retries = 0
+	c.elapsed = c.options.elapsedOffset
+	c.last = 0
+	c.hasLast = false
+	c.options.reset()
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (c *ChannelSequence) Current() (time.Duration, bool) {
+	return c.last, c.hasLast
+}
+
+// Attempts returns the number of delays received since construction or
+// Reset.
+func (c *ChannelSequence) Attempts() int {
+	return c.retries
+}
+
+// Elapsed returns the sum of the delays received so far, plus the offset
+// set with WithElapsedOffset.
+func (c *ChannelSequence) Elapsed() time.Duration {
+	return c.elapsed
+}
+
+// RemainingRetries returns the retries c has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (c *ChannelSequence) RemainingRetries() int {
+	return remainingRetries(c.options, c.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (c *ChannelSequence) budget() (limit, spent time.Duration) {
+	return c.options.maxElapsed, c.options.spent(c.elapsed)
+}
+
+// Remaining returns the elapsed budget c has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (c *ChannelSequence) Remaining() time.Duration {
+	return remaining(c)
+}
+
+// Iter returns an iterator over the delays received from the channel, see
+// Iterate. Unless WithFallback is set, each step blocks until a delay is
+// sent.
+func (c *ChannelSequence) Iter() iter.Seq[time.Duration] {
+	return Iterate(c)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of c ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done, even while
+// waiting for the channel.
+func (c *ChannelSequence) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, c, c.options, now)
+}
@@ -0,0 +1,103 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChannelSequence(t *testing.T) {
+	t.Run("reads delays until closed", func(t *testing.T) {
+		ch := make(chan time.Duration, 3)
+		ch <- 10 * time.Millisecond
+		ch <- 30 * time.Millisecond
+		ch <- 20 * time.Millisecond
+		close(ch)
+
+		c := NewChannelSequence(ch)
+		for i, want := range []time.Duration{10, 30, 20} {
+			d, ok := c.Next()
+			if !ok || d != want*time.Millisecond {
+				t.Errorf("Call %d: expected (%v, true), got (%v, %v)", i+1, want*time.Millisecond, d, ok)
+			}
+		}
+		if d, ok := c.Next(); ok || d != 0 {
+			t.Errorf("Expected (0, false) after the channel closed, got (%v, %v)", d, ok)
+		}
+	})
+
+	t.Run("blocks by default", func(t *testing.T) {
+		ch := make(chan time.Duration)
+		c := NewChannelSequence(ch)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			ch <- time.Second
+		}()
+		if d, ok := c.Next(); !ok || d != time.Second {
+			t.Errorf("Expected (1s, true), got (%v, %v)", d, ok)
+		}
+	})
+
+	t.Run("with fallback", func(t *testing.T) {
+		ch := make(chan time.Duration, 1)
+		c := NewChannelSequence(ch, WithChannelFallback(time.Second))
+
+		if d, ok := c.Next(); !ok || d != time.Second {
+			t.Errorf("Expected fallback (1s, true), got (%v, %v)", d, ok)
+		}
+		ch <- time.Millisecond
+		if d, _ := c.Next(); d != time.Millisecond {
+			t.Errorf("Expected the sent delay, got %v", d)
+		}
+	})
+
+	t.Run("honors limits", func(t *testing.T) {
+		ch := make(chan time.Duration, 10)
+		for i := 0; i < 10; i++ {
+			ch <- 100 * time.Millisecond
+		}
+
+		c := NewChannelSequence(ch, WithMaxRetries(3))
+		for i := 0; i < 3; i++ {
+			c.Next()
+		}
+		if _, ok := c.Next(); ok {
+			t.Error("Expected max retries to apply")
+		}
+		if len(ch) != 7 {
+			t.Errorf("Expected no delay to be consumed once exhausted, %d left", len(ch))
+		}
+
+		c = NewChannelSequence(ch, WithMaxElapsed(250*time.Millisecond))
+		c.Next()
+		c.Next()
+		if _, ok := c.Next(); ok {
+			t.Error("Expected max elapsed to apply")
+		}
+
+		// Reset does not rewind the channel
+		c.Reset()
+		if d, ok := c.Next(); !ok || d != 100*time.Millisecond || len(ch) != 3 {
+			t.Errorf("Expected the next delay from the channel, got (%v, %v) with %d left", d, ok, len(ch))
+		}
+	})
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		ch := make(chan time.Duration, 1)
+		c := NewChannelSequence(ch)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if d, ok := c.NextContext(ctx); ok || d != 0 {
+			t.Errorf("Expected (0, false) once ctx is done, got (%v, %v)", d, ok)
+		}
+		if c.Attempts() != 0 {
+			t.Errorf("Expected no attempt to be counted, got %d", c.Attempts())
+		}
+
+		ch <- time.Second
+		if d, ok := c.NextContext(context.Background()); !ok || d != time.Second {
+			t.Errorf("Expected the sent delay, got (%v, %v)", d, ok)
+		}
+	})
+}
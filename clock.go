@@ -0,0 +1,19 @@
+package backoff
+
+import "time"
+
+// Clock abstracts the source of the current time. It is used by options
+// that measure elapsed time against the wall clock instead of summing the
+// returned delays, and allows tests to control time deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// systemClock implements Clock using the standard library time package.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,135 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock for tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.now = t
+}
+
+func TestWithStartTime(t *testing.T) {
+	t.Run("time spent before first Next counts", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		start := clock.Now().Add(-50 * time.Second)
+
+		c := NewConstant(time.Second,
+			WithClock(clock),
+			WithStartTime(start),
+			WithMaxElapsed(time.Minute))
+
+		_, ok := c.Next()
+		if !ok {
+			t.Fatal("Next() should succeed with 10s of budget left")
+		}
+
+		clock.Advance(10 * time.Second)
+		d, ok := c.Next()
+		if ok {
+			t.Error("Next() should fail once the budget measured from start is used up")
+		}
+		if d != 0 {
+			t.Errorf("Expected duration 0 when elapsed exceeded, got %v", d)
+		}
+	})
+
+	t.Run("returned delays are not counted", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		e := NewExponential(time.Second, 2.0,
+			WithClock(clock),
+			WithStartTime(clock.Now()),
+			WithMaxElapsed(10*time.Second))
+
+		// Without the clock advancing, only the upcoming delay counts.
+		for i, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+			d, ok := e.Next()
+			if !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+			if d != expected {
+				t.Errorf("Call %d: expected %v, got %v", i+1, expected, d)
+			}
+		}
+
+		// 16s alone exceeds the 10s budget.
+		if _, ok := e.Next(); ok {
+			t.Error("Expected Next() to fail when the next delay exceeds the budget")
+		}
+	})
+
+	t.Run("decorrelated", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		d := NewDecorrelated(100*time.Millisecond, 3.0,
+			WithClock(clock),
+			WithStartTime(clock.Now()),
+			WithMaxElapsed(time.Second))
+
+		if _, ok := d.Next(); !ok {
+			t.Fatal("First Next() should succeed")
+		}
+
+		clock.Advance(time.Second)
+		if _, ok := d.Next(); ok {
+			t.Error("Next() should fail after the wall-clock budget is spent")
+		}
+	})
+}
+
+func TestElapsedClockJumps(t *testing.T) {
+	t.Run("backward jump does not reset elapsed", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+		start := clock.Now()
+
+		c := NewConstant(time.Second,
+			WithClock(clock),
+			WithStartTime(start),
+			WithMaxElapsed(time.Minute))
+
+		clock.Advance(time.Minute)
+		if _, ok := c.Next(); ok {
+			t.Fatal("Next() should fail once the budget is spent")
+		}
+
+		// Wall clock is corrected back by an hour
+		clock.Set(start.Add(-time.Hour))
+		if _, ok := c.Next(); ok {
+			t.Error("Next() should keep failing after a backward clock jump")
+		}
+	})
+
+	t.Run("monotonic system clock", func(t *testing.T) {
+		start := time.Now()
+		e := NewExponential(time.Millisecond, 2.0,
+			WithStartTime(start),
+			WithMaxElapsed(time.Hour))
+
+		// start carries a monotonic reading, so only real time counts
+		if got := e.options.spent(0); got < 0 || got > time.Minute {
+			t.Errorf("Expected a small non-negative elapsed time, got %v", got)
+		}
+
+		// A start time without a monotonic reading falls back to wall time
+		e = NewExponential(time.Millisecond, 2.0,
+			WithStartTime(start.Round(0).Add(-time.Hour)),
+			WithMaxElapsed(time.Hour))
+		if _, ok := e.Next(); ok {
+			t.Error("Expected Next() to fail for a start time an hour ago")
+		}
+	})
+}
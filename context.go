@@ -0,0 +1,51 @@
+package backoff
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// contextKey is the type for context keys defined in this package.
+type contextKey int
+
+const (
+	retryIDKey contextKey = iota // key for the retry sequence ID
+	attemptKey                   // key for the attempt number
+)
+
+// RetryIDFromContext returns the retry sequence ID stored in ctx by
+// RetryWithContext, RetryResult or RetryWithBreaker. The ID is stable
+// across all attempts of a single call and unique per call, so it can be
+// used to correlate the logs of one retry sequence.
+func RetryIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(retryIDKey).(string)
+	return id, ok
+}
+
+// AttemptFromContext returns the attempt number stored in ctx by
+// RetryWithContext, RetryResult or RetryWithBreaker. Attempts are numbered
+// starting at 1.
+func AttemptFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(attemptKey).(int)
+	return n, ok
+}
+
+// withRetryID returns a copy of ctx carrying the retry sequence ID.
+func withRetryID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, retryIDKey, id)
+}
+
+// withAttempt returns a copy of ctx carrying the attempt number.
+func withAttempt(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, attemptKey, n)
+}
+
+// newRetryID returns a random version 4 UUID string.
+func newRetryID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,53 @@
+package backoff
+
+import (
+	"iter"
+	"time"
+)
+
+// Cycles returns an iterator over up to n cycles of s. Each cycle runs the
+// sequence until Next() reports exhaustion and yields (cycle, delay) pairs,
+// with cycle counting from 0; s is reset before every cycle but the first.
+// This models supervision loops that try hard, give up, wait, and then try
+// hard again.
+//
+// Reset only clears per-cycle state. Lifetime limits that survive it, such
+// as a SharedBudget or a wall-clock WithStartTime, still apply across
+// cycles; once a whole cycle yields no delay, the iteration ends early.
+//
+// If the loop body stops the iteration, s is left as it was after the last
+// yielded delay and is not reset.
+//
+// Example:
+//
+//	for cycle, d := range Cycles(b, 3) {
+//		time.Sleep(d)
+//		if err := start(); err == nil {
+//			break
+//		}
+//		log.Printf("cycle %d: restart failed", cycle)
+//	}
+func Cycles(s Sequence, n int) iter.Seq2[int, time.Duration] {
+	return func(yield func(int, time.Duration) bool) {
+		for cycle := 0; cycle < n; cycle++ {
+			if cycle > 0 {
+				s.Reset()
+			}
+
+			yielded := false
+			for {
+				d, ok := s.Next()
+				if !ok {
+					break
+				}
+				yielded = true
+				if !yield(cycle, d) {
+					return
+				}
+			}
+			if !yielded {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCycles(t *testing.T) {
+	t.Run("resets between cycles", func(t *testing.T) {
+		e := NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(3))
+
+		var cycles []int
+		var delays []time.Duration
+		for cycle, d := range Cycles(e, 2) {
+			cycles = append(cycles, cycle)
+			delays = append(delays, d)
+		}
+
+		wantCycles := []int{0, 0, 0, 1, 1, 1}
+		wantDelays := []time.Duration{10, 20, 40, 10, 20, 40}
+		if len(delays) != len(wantDelays) {
+			t.Fatalf("Expected %d delays, got %v", len(wantDelays), delays)
+		}
+		for i := range delays {
+			if cycles[i] != wantCycles[i] || delays[i] != wantDelays[i]*time.Millisecond {
+				t.Errorf("Step %d: expected (%d, %v), got (%d, %v)", i, wantCycles[i], wantDelays[i]*time.Millisecond, cycles[i], delays[i])
+			}
+		}
+	})
+
+	t.Run("stopping leaves state", func(t *testing.T) {
+		e := NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(3))
+
+		for _, d := range Cycles(e, 2) {
+			if d == 20*time.Millisecond {
+				break
+			}
+		}
+		if d, ok := e.Current(); !ok || d != 20*time.Millisecond {
+			t.Errorf("Expected sequence to remain at the last delay, got (%v, %v)", d, ok)
+		}
+		if d, _ := e.Next(); d != 40*time.Millisecond {
+			t.Errorf("Expected sequence to continue where it stopped, got %v", d)
+		}
+	})
+
+	t.Run("lifetime limits end the iteration", func(t *testing.T) {
+		budget := NewSharedBudget(4)
+		c := NewConstant(time.Millisecond, WithMaxRetries(3), WithSharedBudget(budget))
+
+		count := 0
+		for range Cycles(c, 10) {
+			count++
+		}
+		if count != 4 {
+			t.Errorf("Expected the shared budget to cap the delays at 4, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,286 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"math"
+	"time"
+)
+
+// Deadline implements a backoff strategy derived top-down from a time
+// budget: the total duration is divided into a fixed number of delays.
+//
+// Use Deadline when a retry policy is expressed as "about N retries within
+// T", which matches how many SLAs are written, rather than by base delay
+// and growth factor.
+type Deadline struct {
+	options  *options
+	total    time.Duration // total duration to divide
+	attempts int           // number of delays
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	planned time.Duration // sum of un-jittered delays returned so far
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewDeadline creates a new deadline backoff strategy dividing total into
+// the given number of delays.
+//
+// Parameters:
+//   - total: The duration the delays should add up to
+//   - attempts: The number of delays
+//   - opts: Optional configuration functions
+//
+// By default the delays are evenly spaced. With WithDeadlineFactor, each
+// delay is factor times the previous one, so that later retries are spaced
+// further apart. Without jitter or bounds, the delays add up to exactly
+// total. After the given number of delays, Next() returns (0, false).
+//
+// Example:
+//
+//	// 6 retries within a minute: 10s each
+//	d := NewDeadline(time.Minute, 6)
+//
+//	// 6 retries within a minute, each twice as far apart as the previous
+//	d := NewDeadline(time.Minute, 6, WithDeadlineFactor(2.0))
+func NewDeadline(total time.Duration, attempts int, opts ...Option) *Deadline {
+	o := applyOptions(opts)
+
+	dl := &Deadline{
+		options:  o,
+		total:    max(total, 0),
+		attempts: attempts,
+		elapsed:  o.elapsedOffset,
+	}
+	dl.skip(o.startSkip())
+
+	return dl
+}
+
+// Next returns the next slice of the total duration, subject to jitter
+// and min/max bounds.
+//
+// Returns:
+//   - time.Duration: The calculated delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+func (dl *Deadline) Next() (time.Duration, bool) {
+	if dl.retries >= dl.attempts || dl.options.outOfRetries(dl.retries) {
+		dl.last, dl.hasLast = 0, false
+		return 0, false
+	}
+
+	slice := dl.slice(dl.retries)
+	if dl.retries == dl.attempts-1 {
+		slice = dl.total - dl.planned // absorb rounding errors
+	}
+
+	d := dl.options.applyJitter(slice, dl.elapsed)
+	d = applyBounds(d, dl.options.minInterval, dl.options.maxAt(dl.retries))
+	d = dl.options.firstRange(d, dl.retries)
+	d = dl.options.scaled(d)
+	if dl.options.maxElapsed > 0 && !dl.options.free(dl.retries) && d >= dl.options.maxElapsed-dl.options.spent(dl.elapsed) {
+		dl.last, dl.hasLast = 0, false
+		return 0, false
+	}
+
+	if !dl.options.acquire() {
+		dl.last, dl.hasLast = 0, false
+		return 0, false
+	}
+
+	d = dl.options.finish(d)
+	dl.elapsed += dl.options.charge(dl.retries, d)
+	dl.retries++
+	dl.planned += slice
+	dl.last, dl.hasLast = d, true
+	return d, true
+}
+
+// NextContext is like Next, but returns (0, false) without using up a
+// slice if ctx is done.
+func (dl *Deadline) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, dl)
+}
+
+// slice returns the un-jittered delay for the given retry index.
+// With factor f and n attempts, delay i is total * f^i * (f-1) / (f^n-1),
+// or total / n for f = 1. For f > 1 the weight is computed as
+// f^(i-n) * (f-1) / (1-f^-n) instead, so that f^n cannot overflow and
+// leave all but the last delay at 0.
+func (dl *Deadline) slice(retry int) time.Duration {
+	f := dl.options.deadlineFactor
+	n := float64(dl.attempts)
+	if !(f > 0) || f == 1 || math.IsInf(f, 0) {
+		return time.Duration(float64(dl.total) / n)
+	}
+
+	var w float64
+	if f > 1 {
+		w = math.Pow(f, float64(retry)-n) * (f - 1) / (1 - math.Pow(f, -n))
+	} else {
+		w = math.Pow(f, float64(retry)) * (1 - f) / (1 - math.Pow(f, n))
+	}
+	if math.IsNaN(w) || math.IsInf(w, 0) {
+		return 0
+	}
+	return time.Duration(float64(dl.total) * w)
+}
+
+// skip uses up n slices, as WithRandomStart does at construction.
+func (dl *Deadline) skip(n int) {
+	for ; n > 0 && dl.retries < dl.attempts; n-- {
+		dl.planned += dl.slice(dl.retries)
+		dl.retries++
+	}
+}
+
+// DelayAt returns the delay the strategy would produce for the given
+// attempt (0-based) without jitter, without modifying its state. Returns
+// 0 for attempts outside the schedule.
+func (dl *Deadline) DelayAt(attempt int) time.Duration {
+	if attempt < 0 || attempt >= dl.attempts {
+		return 0
+	}
+
+	d := dl.slice(attempt)
+	if attempt == dl.attempts-1 {
+		var planned time.Duration
+		for i := 0; i < attempt; i++ {
+			planned += dl.slice(i)
+		}
+		d = dl.total - planned
+	}
+	d = applyBounds(d, dl.options.minInterval, dl.options.maxAt(attempt))
+	return dl.options.firstRange(d, attempt)
+}
+
+// Reset resets the deadline backoff to its initial state.
+// This clears the retry count and the delay reported by Current, and
+// restores the elapsed time to its initial offset.
+func (dl *Deadline) Reset() {
+	if !dl.options.allowReset() {
+		return
+	}
+	dl.retries = 0
+	dl.elapsed = dl.options.elapsedOffset
+	dl.planned = 0
+	dl.last = 0
+	dl.hasLast = false
+	dl.options.reset()
+}
+
+// Deadline has no SoftReset method: its delays are planned to add up to
+// the total, and rewinding part of the plan would break that guarantee.
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (dl *Deadline) Current() (time.Duration, bool) {
+	return dl.last, dl.hasLast
+}
+
+// Attempts returns the number of slices returned since construction or
+// Reset.
+func (dl *Deadline) Attempts() int {
+	return dl.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (dl *Deadline) Elapsed() time.Duration {
+	return dl.elapsed
+}
+
+// RemainingRetries returns the retries dl has left under WithMaxRetries,
+// or -1 if retries are unlimited. The sequence may end earlier when all
+// slices are used up.
+func (dl *Deadline) RemainingRetries() int {
+	return remainingRetries(dl.options, dl.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (dl *Deadline) budget() (limit, spent time.Duration) {
+	return dl.options.maxElapsed, dl.options.spent(dl.elapsed)
+}
+
+// Remaining returns the elapsed budget dl has left under WithMaxElapsed,
+// or math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (dl *Deadline) Remaining() time.Duration {
+	return remaining(dl)
+}
+
+// Derive returns a deadline backoff that starts over from the first slice,
+// but is charged with the elapsed time of dl, so that a nested operation
+// stops at the same deadline. The slices still divide the full total.
+func (dl *Deadline) Derive() *Deadline {
+	return derive(dl, dl)
+}
+
+// Clone returns an independent copy of dl, including the slices used up so
+// far.
+func (dl *Deadline) Clone() *Deadline {
+	return clone(dl)
+}
+
+// Iter returns an iterator over the remaining slices of dl, see Iterate.
+func (dl *Deadline) Iter() iter.Seq[time.Duration] {
+	return Iterate(dl)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each slice
+// of dl ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (dl *Deadline) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, dl, dl.options, now)
+}
+
+// Describe returns the configuration of the deadline backoff. Factor is
+// the value set with WithDeadlineFactor, or 0 for evenly spaced delays.
+func (dl *Deadline) Describe() Description {
+	return dl.options.describe(Description{
+		Kind:     KindDeadline,
+		Factor:   dl.options.deadlineFactor,
+		Total:    dl.total,
+		Attempts: dl.attempts,
+	})
+}
+
+// JitterSpread returns the standard deviation of the next slice of dl; see
+// Common methods.
+func (dl *Deadline) JitterSpread(n int) time.Duration {
+	return jitterSpread(dl, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// slices of dl, which are all equal when evenly spaced without jitter; see
+// Common methods.
+func (dl *Deadline) DistinctDelays(n int) int {
+	return distinctDelays(dl, n)
+}
+
+// CumulativeDelay returns the sum of the first attempts slices without
+// jitter, ignoring the configured limits. Without bounds or scaling, all
+// slices add up to the total.
+func (dl *Deadline) CumulativeDelay(attempts int) time.Duration {
+	return cumulativeDelay(dl, attempts)
+}
+
+// fork returns a copy of the deadline backoff including its state.
+func (dl *Deadline) fork(mod func(*options)) Sequence {
+	f := *dl
+	f.options = forkOptions(dl.options, mod)
+	return &f
+}
+
+// WithOverrides returns a new deadline backoff with the same total, number
+// of slices and options, plus opts applied on top, starting from its
+// initial state.
+func (dl *Deadline) WithOverrides(opts ...Option) Sequence {
+	return NewDeadline(dl.total, dl.attempts, dl.options.with(opts)...)
+}
@@ -0,0 +1,112 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadline(t *testing.T) {
+	t.Run("even spacing", func(t *testing.T) {
+		dl := NewDeadline(time.Minute, 6)
+
+		var total time.Duration
+		for i := 0; i < 6; i++ {
+			d, ok := dl.Next()
+			if !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+			if d != 10*time.Second {
+				t.Errorf("Call %d: expected 10s, got %v", i+1, d)
+			}
+			total += d
+		}
+		if total != time.Minute {
+			t.Errorf("Expected delays to add up to 1m, got %v", total)
+		}
+
+		if d, ok := dl.Next(); ok || d != 0 {
+			t.Errorf("Expected (0, false) after all attempts, got (%v, %v)", d, ok)
+		}
+	})
+
+	t.Run("exponential weighting", func(t *testing.T) {
+		dl := NewDeadline(15*time.Second, 4, WithDeadlineFactor(2.0))
+
+		expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+		for i, exp := range expected {
+			if at := dl.DelayAt(i); at != exp {
+				t.Errorf("DelayAt(%d): expected %v, got %v", i, exp, at)
+			}
+			d, _ := dl.Next()
+			if d != exp {
+				t.Errorf("Call %d: expected %v, got %v", i+1, exp, d)
+			}
+		}
+	})
+
+	t.Run("sum is exact despite rounding", func(t *testing.T) {
+		total := 10*time.Second + 7
+		dl := NewDeadline(total, 7, WithDeadlineFactor(1.3))
+
+		var sum time.Duration
+		for {
+			d, ok := dl.Next()
+			if !ok {
+				break
+			}
+			sum += d
+		}
+		if sum != total {
+			t.Errorf("Expected delays to add up to %v, got %v", total, sum)
+		}
+	})
+
+	t.Run("large factor does not overflow", func(t *testing.T) {
+		// 10^400 overflows a float64
+		dl := NewDeadline(100*time.Second, 400, WithDeadlineFactor(10))
+		if d := dl.DelayAt(399); d < 89*time.Second || d > 91*time.Second {
+			t.Errorf("Expected the last delay to be about 90s, got %v", d)
+		}
+		if d := dl.DelayAt(398); d < 8900*time.Millisecond || d > 9100*time.Millisecond {
+			t.Errorf("Expected the second to last delay to be about 9s, got %v", d)
+		}
+	})
+
+	t.Run("stops at max elapsed", func(t *testing.T) {
+		for _, tt := range []struct {
+			maxElapsed time.Duration
+			want       int
+		}{
+			{time.Minute + time.Nanosecond, 6},
+			{time.Minute, 5},
+		} {
+			dl := NewDeadline(time.Minute, 6, WithMaxElapsed(tt.maxElapsed))
+			attempts := 0
+			for {
+				if _, ok := dl.Next(); !ok {
+					break
+				}
+				attempts++
+			}
+			if attempts != tt.want {
+				t.Errorf("Max elapsed %v: expected %d attempts, got %d", tt.maxElapsed, tt.want, attempts)
+			}
+		}
+	})
+
+	t.Run("no attempts", func(t *testing.T) {
+		if _, ok := NewDeadline(time.Minute, 0).Next(); ok {
+			t.Error("Expected Next() to fail with zero attempts")
+		}
+	})
+
+	t.Run("reset functionality", func(t *testing.T) {
+		dl := NewDeadline(time.Minute, 2)
+		dl.Next()
+		dl.Next()
+		dl.Reset()
+		if d, ok := dl.Next(); !ok || d != 30*time.Second {
+			t.Errorf("Expected (30s, true) after Reset(), got (%v, %v)", d, ok)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package backoff
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Strategy kinds reported in Description.Kind.
+const (
+	KindConstant     = "constant"
+	KindExponential  = "exponential"
+	KindDecorrelated = "decorrelated"
+	KindHybrid       = "hybrid"
+	KindWeighted     = "weighted"
+	KindDeadline     = "deadline"
+	KindPacer        = "pacer"
+	KindProbing      = "probing"
+)
+
+// Description is a snapshot of the configuration of a strategy, without
+// its runtime state. Fields that do not apply to a kind are left zero.
+//
+// It covers the parameters and the most common options; options such as
+// WithScale or WithSharedBudget are not part of the description.
+type Description struct {
+	Kind string // one of the Kind constants
+
+	Base       time.Duration   // interval, base, or initial delay
+	Factor     float64         // growth factor
+	Increment  time.Duration   // Hybrid: linear increment per retry
+	SwitchAt   int             // Hybrid: retry index of the switch to exponential
+	Choices    []WeightedDelay // WeightedRandom: candidate delays
+	Total      time.Duration   // Deadline: duration the delays add up to
+	Attempts   int             // Deadline: number of delays
+	Spread     time.Duration   // Pacer: maximum deviation from Base
+	Plateau    time.Duration   // Probing: delay at which growth stops
+	ProbeEvery int             // Probing: plateau delays between probes
+	ProbeDelay time.Duration   // Probing: delay of a probe
+
+	MinInterval time.Duration // 0 = no minimum
+	MaxInterval time.Duration // 0 = no maximum
+	MaxRetries  int           // -1 = unlimited
+	MaxElapsed  time.Duration // 0 = no time limit
+	Jitter      JitterSpec    // jitter strategy and its parameters
+}
+
+// describe fills in the options shared by all kinds.
+func (o *options) describe(d Description) Description {
+	d.MinInterval = o.minInterval
+	d.MaxInterval = o.maxInterval
+	d.MaxRetries = o.maxRetries
+	d.MaxElapsed = o.maxElapsed
+	d.Jitter = jitterSpec(o.jitter)
+	return d
+}
+
+// jitterSpec returns the spec of j. Custom jitter strategies are
+// described by their name only, which New rejects.
+func jitterSpec(j Jitter) JitterSpec {
+	spec, err := SpecOf(j)
+	if err != nil {
+		return JitterSpec{Type: jitterName(j)}
+	}
+	return spec
+}
+
+// jitterName returns the name of a jitter strategy, as returned by its
+// String method. Strategies without one are named after their type.
+func jitterName(j Jitter) string {
+	switch j := j.(type) {
+	case nil:
+		return "none"
+	case fmt.Stringer:
+		return j.String()
+	default:
+		return fmt.Sprintf("%T", j)
+	}
+}
+
+// EqualConfig reports whether a and b have the same configuration, as
+// returned by their Describe methods. Runtime state, such as the number
+// of retries so far, is ignored.
+//
+// Returns false if either sequence has no Describe method.
+//
+// Example:
+//
+//	want := NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(5))
+//	if !EqualConfig(loadPolicy(cfg), want) {
+//		t.Error("unexpected retry policy")
+//	}
+func EqualConfig(a, b Sequence) bool {
+	da, ok := a.(interface{ Describe() Description })
+	if !ok {
+		return false
+	}
+	db, ok := b.(interface{ Describe() Description })
+	if !ok {
+		return false
+	}
+
+	x, y := da.Describe(), db.Describe()
+	return x.Kind == y.Kind &&
+		x.Base == y.Base &&
+		x.Factor == y.Factor &&
+		x.Increment == y.Increment &&
+		x.SwitchAt == y.SwitchAt &&
+		slices.Equal(x.Choices, y.Choices) &&
+		x.Total == y.Total &&
+		x.Attempts == y.Attempts &&
+		x.Spread == y.Spread &&
+		x.Plateau == y.Plateau &&
+		x.ProbeEvery == y.ProbeEvery &&
+		x.ProbeDelay == y.ProbeDelay &&
+		x.MinInterval == y.MinInterval &&
+		x.MaxInterval == y.MaxInterval &&
+		x.MaxRetries == y.MaxRetries &&
+		x.MaxElapsed == y.MaxElapsed &&
+		x.Jitter.Type == y.Jitter.Type &&
+		maps.Equal(x.Jitter.Params, y.Jitter.Params)
+}
+
+// New creates a strategy from its description, as returned by Describe.
+// Together they close the loop for storing and sharing policies:
+// Describe, serialize, deserialize, New yields an equivalent strategy,
+// i.e. EqualConfig reports true.
+//
+// All fields are taken literally; in particular, MaxRetries 0 disables
+// retries, use -1 for unlimited retries. The jitter is rebuilt from its
+// JitterSpec, with its parameters; an empty type means none. Options in
+// opts are applied last.
+//
+// Returns an error for unknown kinds and jitters, and if a field required
+// by the kind is not positive: Base for most kinds, Total and Attempts for
+// Deadline, and at least one choice for WeightedRandom.
+//
+// Example:
+//
+//	var d Description
+//	if err := json.Unmarshal(data, &d); err != nil {
+//		return err
+//	}
+//	b, err := New(d)
+func New(d Description, opts ...Option) (Sequence, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	var j Jitter = &NoneJitter{}
+	if d.Jitter.Type != "" {
+		var err error
+		if j, err = d.Jitter.Jitter(); err != nil {
+			return nil, err
+		}
+	}
+
+	opts = append([]Option{
+		WithMinInterval(d.MinInterval),
+		WithMaxInterval(d.MaxInterval),
+		WithMaxRetries(d.MaxRetries),
+		WithMaxElapsed(d.MaxElapsed),
+		WithJitterStrategy(j),
+	}, opts...)
+
+	switch d.Kind {
+	case KindConstant:
+		return NewConstant(d.Base, opts...), nil
+	case KindExponential:
+		return NewExponential(d.Base, d.Factor, opts...), nil
+	case KindDecorrelated:
+		return NewDecorrelated(d.Base, d.Factor, opts...), nil
+	case KindHybrid:
+		return NewHybrid(d.Base, d.Increment, d.SwitchAt, d.Factor, opts...), nil
+	case KindWeighted:
+		return NewWeightedRandom(d.Choices, opts...), nil
+	case KindDeadline:
+		return NewDeadline(d.Total, d.Attempts, append([]Option{WithDeadlineFactor(d.Factor)}, opts...)...), nil
+	case KindPacer:
+		return NewPacer(d.Base, d.Spread, opts...), nil
+	default: // KindProbing, see validate
+		return NewProbing(d.Base, d.Factor, d.Plateau, d.ProbeEvery, d.ProbeDelay, opts...), nil
+	}
+}
+
+// validate checks that the kind is known and its required fields are set.
+func (d Description) validate() error {
+	var missing string
+	switch d.Kind {
+	case KindConstant, KindExponential, KindDecorrelated, KindHybrid, KindPacer, KindProbing:
+		if d.Base <= 0 {
+			missing = "Base"
+		}
+	case KindWeighted:
+		if len(d.Choices) == 0 {
+			missing = "Choices"
+		}
+	case KindDeadline:
+		if d.Total <= 0 {
+			missing = "Total"
+		} else if d.Attempts <= 0 {
+			missing = "Attempts"
+		}
+	case "":
+		return errors.New("backoff: description has no kind")
+	default:
+		return fmt.Errorf("backoff: unknown kind %q", d.Kind)
+	}
+
+	if missing != "" {
+		return fmt.Errorf("backoff: %s description requires a positive %s", d.Kind, missing)
+	}
+	return nil
+}
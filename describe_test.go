@@ -0,0 +1,225 @@
+package backoff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	e := NewExponential(100*time.Millisecond, 2.0,
+		WithMaxInterval(5*time.Second),
+		WithMaxRetries(5),
+		WithJitter())
+	e.Next()
+
+	got := e.Describe()
+	if got.Kind != KindExponential || got.Base != 100*time.Millisecond || got.Factor != 2.0 {
+		t.Errorf("Unexpected parameters in %+v", got)
+	}
+	if got.MaxInterval != 5*time.Second || got.MinInterval != 0 || got.MaxRetries != 5 || got.MaxElapsed != 0 {
+		t.Errorf("Unexpected bounds or limits in %+v", got)
+	}
+	if got.Jitter.Type != "equal" || got.Jitter.Params != nil {
+		t.Errorf("Expected jitter %q without parameters, got %+v", "equal", got.Jitter)
+	}
+
+	dcr := NewDecorrelated(100*time.Millisecond, 3.0)
+	if got := dcr.Describe().MaxInterval; got != 30*time.Second {
+		t.Errorf("Expected default max interval 30s in description, got %v", got)
+	}
+}
+
+func TestEqualConfig(t *testing.T) {
+	choices := []WeightedDelay{{Delay: time.Second, Weight: 1}, {Delay: 2 * time.Second, Weight: 3}}
+
+	t.Run("same config", func(t *testing.T) {
+		pairs := []struct {
+			name string
+			a, b Sequence
+		}{
+			{"Constant", NewConstant(time.Second, WithMaxRetries(3)), NewConstant(time.Second, WithMaxRetries(3))},
+			{"Exponential", NewExponential(time.Second, 2.0, WithJitterStrategy(FullJitter{})), NewExponential(time.Second, 2.0, WithJitterStrategy(&FullJitter{}))},
+			{"Decorrelated", NewDecorrelated(time.Second, 3.0), NewDecorrelated(time.Second, 3.0, WithMaxInterval(30*time.Second))},
+			{"Hybrid", NewHybrid(time.Second, time.Second, 3, 2.0), NewHybrid(time.Second, time.Second, 3, 2.0)},
+			{"WeightedRandom", NewWeightedRandom(choices), NewWeightedRandom(choices)},
+			{"Deadline", NewDeadline(time.Minute, 6, WithDeadlineFactor(2.0)), NewDeadline(time.Minute, 6, WithDeadlineFactor(2.0))},
+			{"Pacer", NewPacer(10*time.Second, 2*time.Second), NewPacer(10*time.Second, -2*time.Second)},
+			{"Probing", NewProbing(time.Second, 2.0, time.Minute, 4, time.Second), NewProbing(time.Second, 2.0, time.Minute, 4, time.Second)},
+		}
+
+		for _, p := range pairs {
+			t.Run(p.name, func(t *testing.T) {
+				// Runtime state is ignored
+				p.a.Next()
+				p.a.Next()
+				if !EqualConfig(p.a, p.b) {
+					t.Error("Expected equal configs")
+				}
+			})
+		}
+	})
+
+	t.Run("differing config", func(t *testing.T) {
+		pairs := []struct {
+			name string
+			a, b Sequence
+		}{
+			{"kind", NewConstant(time.Second), NewExponential(time.Second, 2.0)},
+			{"base", NewExponential(time.Second, 2.0), NewExponential(2*time.Second, 2.0)},
+			{"factor", NewExponential(time.Second, 2.0), NewExponential(time.Second, 3.0)},
+			{"bounds", NewExponential(time.Second, 2.0), NewExponential(time.Second, 2.0, WithMinInterval(time.Millisecond))},
+			{"limits", NewConstant(time.Second, WithMaxRetries(3)), NewConstant(time.Second, WithMaxRetries(4))},
+			{"jitter", NewExponential(time.Second, 2.0, WithJitter()), NewExponential(time.Second, 2.0)},
+			{"choices", NewWeightedRandom(choices), NewWeightedRandom(choices[:1])},
+			{"attempts", NewDeadline(time.Minute, 6), NewDeadline(time.Minute, 5)},
+			{"spread", NewPacer(10*time.Second, 2*time.Second), NewPacer(10*time.Second, time.Second)},
+			{"probes", NewProbing(time.Second, 2.0, time.Minute, 4, time.Second), NewProbing(time.Second, 2.0, time.Minute, 3, time.Second)},
+		}
+
+		for _, p := range pairs {
+			t.Run(p.name, func(t *testing.T) {
+				if EqualConfig(p.a, p.b) {
+					t.Error("Expected differing configs")
+				}
+			})
+		}
+	})
+
+	t.Run("without Describe", func(t *testing.T) {
+		s := OnExhausted(NewConstant(time.Second), func(int, time.Duration) {})
+		if EqualConfig(s, s) {
+			t.Error("Expected false for a sequence without Describe")
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		choices := []WeightedDelay{{Delay: time.Second, Weight: 1}, {Delay: 2 * time.Second, Weight: 3}}
+		strategies := []struct {
+			name     string
+			sequence Sequence
+		}{
+			{"Constant", NewConstant(time.Second, WithMaxRetries(3), WithMaxElapsed(time.Minute))},
+			{"Exponential", NewExponential(100*time.Millisecond, 1.5, WithJitter(), WithMaxInterval(5*time.Second))},
+			{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0, WithMinInterval(50*time.Millisecond))},
+			{"Hybrid", NewHybrid(100*time.Millisecond, 50*time.Millisecond, 3, 2.0, WithJitterStrategy(FullJitter{}))},
+			{"WeightedRandom", NewWeightedRandom(choices, WithMaxRetries(10))},
+			{"Deadline", NewDeadline(time.Minute, 6, WithDeadlineFactor(2.0))},
+			{"Pacer", NewPacer(10*time.Second, 2*time.Second, WithJitterStrategy(EqualJitter{}))},
+			{"Probing", NewProbing(100*time.Millisecond, 2.0, 30*time.Second, 4, 500*time.Millisecond, WithMaxElapsed(time.Hour))},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				data, err := json.Marshal(strategy.sequence.(interface{ Describe() Description }).Describe())
+				if err != nil {
+					t.Fatalf("Marshal: %v", err)
+				}
+				var d Description
+				if err := json.Unmarshal(data, &d); err != nil {
+					t.Fatalf("Unmarshal: %v", err)
+				}
+
+				s, err := New(d)
+				if err != nil {
+					t.Fatalf("New: %v", err)
+				}
+				if !EqualConfig(s, strategy.sequence) {
+					t.Errorf("Expected an equivalent strategy from %s", data)
+				}
+			})
+		}
+	})
+
+	t.Run("round trip of every jitter", func(t *testing.T) {
+		jitters := []Jitter{
+			&NoneJitter{},
+			FullJitter{},
+			FullJitterFromZero{},
+			EqualJitter{},
+			ProportionalJitter{Fraction: 0.2},
+			BetaJitter{Alpha: 2, Beta: 5},
+			ElapsedProportionalJitter{Fraction: 0.3},
+			SlottedJitter{Index: 3, Total: 8},
+			fixedJitter{fraction: 0.75},
+			NewAdaptiveJitter(0.1, 0.2, 0.6),
+		}
+
+		for _, j := range jitters {
+			t.Run(jitterName(j), func(t *testing.T) {
+				e := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(j))
+				data, err := json.Marshal(e.Describe())
+				if err != nil {
+					t.Fatalf("Marshal: %v", err)
+				}
+				var d Description
+				if err := json.Unmarshal(data, &d); err != nil {
+					t.Fatalf("Unmarshal: %v", err)
+				}
+
+				s, err := New(d)
+				if err != nil {
+					t.Fatalf("New: %v", err)
+				}
+				if !EqualConfig(s, e) {
+					t.Errorf("Expected an equivalent strategy from %s", data)
+				}
+			})
+		}
+	})
+
+	t.Run("same delays", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(4), WithMaxInterval(time.Second))
+		s, err := New(e.Describe())
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		for i := 0; ; i++ {
+			want, wantOK := e.Next()
+			got, gotOK := s.Next()
+			if got != want || gotOK != wantOK {
+				t.Fatalf("Call %d: expected (%v, %v), got (%v, %v)", i+1, want, wantOK, got, gotOK)
+			}
+			if !wantOK {
+				break
+			}
+		}
+	})
+
+	t.Run("opts are applied last", func(t *testing.T) {
+		beta := BetaJitter{Alpha: 2, Beta: 5}
+		s, err := New(NewExponential(time.Second, 2.0, WithJitterStrategy(beta)).Describe(), WithJitterStrategy(beta))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		if got := s.(*Exponential).options.jitter; got != beta {
+			t.Errorf("Expected %v, got %v", beta, got)
+		}
+	})
+
+	t.Run("invalid descriptions", func(t *testing.T) {
+		descriptions := []struct {
+			name string
+			d    Description
+		}{
+			{"no kind", Description{Base: time.Second}},
+			{"unknown kind", Description{Kind: "fibonacci", Base: time.Second}},
+			{"no base", Description{Kind: KindExponential, Factor: 2.0}},
+			{"no choices", Description{Kind: KindWeighted}},
+			{"no total", Description{Kind: KindDeadline, Attempts: 3}},
+			{"no attempts", Description{Kind: KindDeadline, Total: time.Minute}},
+			{"unknown jitter", Description{Kind: KindConstant, Base: time.Second, Jitter: JitterSpec{Type: "gaussian"}}},
+			{"custom jitter", NewConstant(time.Second, WithJitterStrategy(inflateJitter{})).Describe()},
+		}
+
+		for _, tt := range descriptions {
+			t.Run(tt.name, func(t *testing.T) {
+				if s, err := New(tt.d); err == nil {
+					t.Errorf("Expected an error, got %T", s)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package backoff
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// budgeted is implemented by the strategies that track elapsed time.
+type budgeted interface {
+	// budget returns the configured maxElapsed (0 = no time limit) and
+	// the elapsed time charged against it so far.
+	budget() (limit, spent time.Duration)
+}
+
+// EffectiveDeadline returns the time at which retries will actually stop
+// because of a time limit: the earlier of the deadline of ctx and the end
+// of the elapsed budget of s, i.e. now + (maxElapsed - elapsed).
+//
+// The two budgets are independent: a context deadline shorter than the
+// remaining elapsed budget ends the retries early, and vice versa. Retry
+// and shared budget limits are not taken into account.
+//
+// Returns false if neither ctx nor s has a time limit. Sequences that do
+// not track elapsed time, such as wrappers, only contribute no limit.
+//
+// Example:
+//
+//	if end, ok := EffectiveDeadline(ctx, b, time.Now()); ok {
+//		log.Printf("giving up at the latest at %v", end)
+//	}
+func EffectiveDeadline(ctx context.Context, s Sequence, now time.Time) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+
+	if b, isBudgeted := s.(budgeted); isBudgeted {
+		if limit, spent := b.budget(); limit > 0 {
+			end := now.Add(max(limit-spent, 0))
+			if !ok || end.Before(deadline) {
+				deadline, ok = end, true
+			}
+		}
+	}
+
+	return deadline, ok
+}
+
+// remaining returns the elapsed budget of b that is left, or math.MaxInt64
+// if b has no time limit.
+func remaining(b budgeted) time.Duration {
+	limit, spent := b.budget()
+	if limit <= 0 {
+		return math.MaxInt64
+	}
+	return max(limit-spent, 0)
+}
+
+// derive returns a copy of f reset to its initial state, which starts with
+// the elapsed time of b charged against the same limit, so that it stops
+// at the same deadline. The reset hook and cooldown are kept, but not
+// triggered by the reset of the copy.
+func derive[S forker](f S, b budgeted) S {
+	_, spent := b.budget()
+
+	var o *options
+	s := f.fork(func(c *options) {
+		o = c
+		c.elapsedOffset = spent
+	})
+
+	hook, cooldown := o.resetHook, o.resetCooldown
+	o.resetHook, o.resetCooldown = nil, 0
+	s.Reset()
+	o.resetHook, o.resetCooldown = hook, cooldown
+
+	return s.(S)
+}
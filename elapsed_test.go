@@ -0,0 +1,188 @@
+package backoff
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEffectiveDeadline(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("elapsed budget limits", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(time.Hour))
+		defer cancel()
+
+		c := NewConstant(time.Second, WithMaxElapsed(10*time.Second))
+		c.Next()
+		c.Next()
+
+		end, ok := EffectiveDeadline(ctx, c, now)
+		if !ok || !end.Equal(now.Add(8*time.Second)) {
+			t.Errorf("Expected (%v, true), got (%v, %v)", now.Add(8*time.Second), end, ok)
+		}
+	})
+
+	t.Run("context deadline limits", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(5*time.Second))
+		defer cancel()
+
+		c := NewConstant(time.Second, WithMaxElapsed(time.Minute))
+		end, ok := EffectiveDeadline(ctx, c, now)
+		if !ok || !end.Equal(now.Add(5*time.Second)) {
+			t.Errorf("Expected (%v, true), got (%v, %v)", now.Add(5*time.Second), end, ok)
+		}
+	})
+
+	t.Run("only one source", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(5*time.Second))
+		defer cancel()
+
+		if end, ok := EffectiveDeadline(ctx, NewExponential(time.Second, 2.0), now); !ok || !end.Equal(now.Add(5*time.Second)) {
+			t.Errorf("Expected the context deadline without maxElapsed, got (%v, %v)", end, ok)
+		}
+
+		e := NewExponential(time.Second, 2.0, WithMaxElapsed(3*time.Second))
+		if end, ok := EffectiveDeadline(context.Background(), e, now); !ok || !end.Equal(now.Add(3*time.Second)) {
+			t.Errorf("Expected the elapsed budget without context deadline, got (%v, %v)", end, ok)
+		}
+	})
+
+	t.Run("spent budget", func(t *testing.T) {
+		c := NewConstant(time.Second, WithMaxElapsed(time.Second), WithElapsedOffset(5*time.Second))
+		if end, ok := EffectiveDeadline(context.Background(), c, now); !ok || !end.Equal(now) {
+			t.Errorf("Expected (%v, true) with the budget used up, got (%v, %v)", now, end, ok)
+		}
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		if _, ok := EffectiveDeadline(context.Background(), NewConstant(time.Second), now); ok {
+			t.Error("Expected no deadline")
+		}
+		s := OnExhausted(NewConstant(time.Second, WithMaxElapsed(time.Second)), func(int, time.Duration) {})
+		if _, ok := EffectiveDeadline(context.Background(), s, now); ok {
+			t.Error("Expected no deadline for a sequence that does not track elapsed time")
+		}
+	})
+}
+
+func TestRemaining(t *testing.T) {
+	c := NewConstant(time.Second, WithMaxElapsed(10*time.Second))
+	if got := c.Remaining(); got != 10*time.Second {
+		t.Errorf("Expected 10s, got %v", got)
+	}
+	c.Next()
+	c.Next()
+	if got := c.Remaining(); got != 8*time.Second {
+		t.Errorf("Expected 8s, got %v", got)
+	}
+
+	if got := NewConstant(time.Second).Remaining(); got != math.MaxInt64 {
+		t.Errorf("Expected math.MaxInt64 without a time limit, got %v", got)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	t.Run("child stops at the inherited deadline", func(t *testing.T) {
+		parent := NewExponential(100*time.Millisecond, 2.0, WithMaxElapsed(time.Second))
+		parent.Next() // 100ms
+		parent.Next() // 200ms
+
+		child := parent.Derive()
+		if got := child.Remaining(); got != 700*time.Millisecond {
+			t.Fatalf("Expected the child to inherit 700ms, got %v", got)
+		}
+
+		// 100ms + 200ms fit, another 400ms would exceed the 700ms left
+		expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+		for i, want := range expected {
+			if got, ok := child.Next(); !ok || got != want {
+				t.Errorf("Call %d: expected (%v, true), got (%v, %v)", i+1, want, got, ok)
+			}
+		}
+		if _, ok := child.Next(); ok {
+			t.Error("Expected the child to stop at the inherited deadline")
+		}
+
+		// The parent is unaffected by the child, and the child by Reset
+		if got := parent.Remaining(); got != 700*time.Millisecond {
+			t.Errorf("Expected the parent to keep 700ms, got %v", got)
+		}
+		child.Reset()
+		if got := child.Remaining(); got != 700*time.Millisecond {
+			t.Errorf("Expected 700ms after Reset of the child, got %v", got)
+		}
+	})
+
+	t.Run("exhausted parent", func(t *testing.T) {
+		parent := NewConstant(time.Second, WithMaxElapsed(time.Second))
+		parent.Next()
+
+		if _, ok := parent.Derive().Next(); ok {
+			t.Error("Expected the child of an exhausted parent to be exhausted")
+		}
+	})
+
+	t.Run("wall clock", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+		parent := NewConstant(time.Second,
+			WithClock(clock),
+			WithStartTime(clock.now),
+			WithMaxElapsed(10*time.Second))
+		clock.now = clock.now.Add(7 * time.Second)
+
+		child := parent.Derive()
+		if got := child.Remaining(); got != 3*time.Second {
+			t.Errorf("Expected 3s, got %v", got)
+		}
+		clock.now = clock.now.Add(3 * time.Second)
+		if _, ok := child.Next(); ok {
+			t.Error("Expected the child to stop at the parent's deadline")
+		}
+	})
+
+	t.Run("reset hook", func(t *testing.T) {
+		var resets int
+		parent := NewDecorrelated(100*time.Millisecond, 3.0, WithResetHook(func() { resets++ }))
+		child := parent.Derive()
+		if resets != 0 {
+			t.Fatalf("Expected Derive not to call the reset hook, got %d calls", resets)
+		}
+		child.Reset()
+		if resets != 1 {
+			t.Errorf("Expected the child to keep the reset hook, got %d calls", resets)
+		}
+	})
+
+	t.Run("all strategies", func(t *testing.T) {
+		choices := []WeightedDelay{{Delay: time.Second, Weight: 1}}
+		opt := WithMaxElapsed(1500 * time.Millisecond)
+		strategies := map[string]interface {
+			Sequence
+			Remaining() time.Duration
+		}{
+			"Constant":       NewConstant(time.Second, opt).Derive(),
+			"Exponential":    NewExponential(time.Second, 2.0, opt).Derive(),
+			"Decorrelated":   NewDecorrelated(time.Second, 1.0, opt, WithMinInterval(time.Second)).Derive(),
+			"Hybrid":         NewHybrid(time.Second, 0, 3, 2.0, opt).Derive(),
+			"WeightedRandom": NewWeightedRandom(choices, opt).Derive(),
+			"Deadline":       NewDeadline(2*time.Second, 2, opt).Derive(),
+			"Pacer":          NewPacer(time.Second, 0, opt).Derive(),
+			"Probing":        NewProbing(time.Second, 2.0, time.Minute, 4, time.Second, opt).Derive(),
+		}
+		for name, s := range strategies {
+			t.Run(name, func(t *testing.T) {
+				if got := s.Remaining(); got != 1500*time.Millisecond {
+					t.Errorf("Expected 1.5s, got %v", got)
+				}
+				if d, ok := s.Next(); !ok || d != time.Second {
+					t.Errorf("Expected (1s, true), got (%v, %v)", d, ok)
+				}
+				if got := s.Remaining(); got != 500*time.Millisecond {
+					t.Errorf("Expected 500ms after one delay, got %v", got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package backoff
+
+import "context"
+
+// Every runs task repeatedly until ctx is done, waiting the next delay of
+// s after each run. The sequence is reset before every wait, so it paces
+// the runs at a steady rate instead of backing off; combine it with a
+// Pacer or a jittered Constant to turn it into a jittered ticker for
+// background workers.
+//
+// The first run happens immediately. Each run receives ctx. A failed run
+// does not stop the loop; its error is passed to the handler set with
+// WithErrorHandler, if any.
+//
+// Returns:
+//   - the context error once ctx is done
+//   - ErrExhausted if s reports no delay even after Reset, e.g. because
+//     of WithMaxRetries(0) or a used up SharedBudget
+//
+// The retry options WithSleeper, WithDryRun, WithRecover, WithLoadGate and
+// WithDriftObserver apply as in the retry helpers; RetryIf is ignored.
+//
+// Example:
+//
+//	// Refresh the cache every 30s ± 5s until shutdown
+//	err := Every(ctx, NewPacer(30*time.Second, 5*time.Second), refreshCache,
+//		WithErrorHandler(func(err error) {
+//			log.Printf("refresh failed: %v", err)
+//		}))
+func Every(ctx context.Context, s Sequence, task func(context.Context) error, opts ...RetryOption) error {
+	o := applyRetryOptions(opts)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := o.call(func() error { return task(ctx) }); err != nil && o.onError != nil {
+			o.onError(err)
+		}
+
+		s.Reset()
+		d, ok := s.Next()
+		if !ok {
+			return ErrExhausted
+		}
+
+		if _, err := o.wait(ctx, d); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvery(t *testing.T) {
+	t.Run("runs at a steady pace until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var sleeps sleepLog
+		runs := 0
+		err := Every(ctx, NewExponential(time.Second, 2.0, WithMaxRetries(1)), func(context.Context) error {
+			if runs++; runs == 4 {
+				cancel()
+			}
+			return nil
+		}, WithSleeper(&sleeps))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if runs != 4 {
+			t.Errorf("Expected 4 runs, got %d", runs)
+		}
+		// The sequence is reset every time, so it neither grows nor runs out
+		for i, d := range sleeps {
+			if d != time.Second {
+				t.Errorf("Wait %d: expected 1s, got %v", i+1, d)
+			}
+		}
+	})
+
+	t.Run("jittered with a pacer", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var sleeps sleepLog
+		runs := 0
+		Every(ctx, NewPacer(10*time.Second, 2*time.Second), func(context.Context) error {
+			if runs++; runs == 50 {
+				cancel()
+			}
+			return nil
+		}, WithSleeper(&sleeps))
+		for i, d := range sleeps {
+			if d < 8*time.Second || d > 12*time.Second {
+				t.Errorf("Wait %d: %v outside [8s, 12s]", i+1, d)
+			}
+		}
+	})
+
+	t.Run("keeps going after task errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errFail := errors.New("fail")
+		var reported []error
+		runs := 0
+		err := Every(ctx, NewConstant(time.Second), func(context.Context) error {
+			switch runs++; runs {
+			case 2, 3:
+				return errFail
+			case 5:
+				cancel()
+			}
+			return nil
+		}, WithDryRun(), WithErrorHandler(func(err error) {
+			reported = append(reported, err)
+		}))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if runs != 5 {
+			t.Errorf("Expected 5 runs, got %d", runs)
+		}
+		if len(reported) != 2 || reported[0] != errFail || reported[1] != errFail {
+			t.Errorf("Expected 2 reported errors, got %v", reported)
+		}
+
+		// Without a handler, errors are dropped
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		runs = 0
+		err = Every(ctx, NewConstant(time.Second), func(context.Context) error {
+			if runs++; runs == 3 {
+				cancel()
+			}
+			return errFail
+		}, WithDryRun())
+		if !errors.Is(err, context.Canceled) || runs != 3 {
+			t.Errorf("Expected context.Canceled after 3 runs, got %v after %d", err, runs)
+		}
+	})
+
+	t.Run("exhausted sequence", func(t *testing.T) {
+		runs := 0
+		err := Every(context.Background(), NewConstant(time.Second, WithMaxRetries(0)), func(context.Context) error {
+			runs++
+			return nil
+		}, WithDryRun())
+		if !errors.Is(err, ErrExhausted) {
+			t.Errorf("Expected ErrExhausted, got %v", err)
+		}
+		if runs != 1 {
+			t.Errorf("Expected 1 run, got %d", runs)
+		}
+	})
+
+	t.Run("cancelled before the first run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := Every(ctx, NewConstant(time.Second), func(context.Context) error {
+			t.Error("task should not run")
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
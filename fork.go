@@ -0,0 +1,136 @@
+package backoff
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// forker is implemented by strategies that can be copied together with
+// their current state.
+type forker interface {
+	// fork returns an independent copy of the strategy, including its
+	// state. The copy gets its own options, which mod may adjust.
+	fork(mod func(*options)) Sequence
+}
+
+// forkOptions returns a copy of o with mod applied.
+func forkOptions(o *options, mod func(*options)) *options {
+	c := *o
+	if mod != nil {
+		mod(&c)
+	}
+	return &c
+}
+
+// clone returns an independent copy of f including its state, with a
+// generator of its own where cloneRand can make one.
+func clone[S forker](f S) S {
+	return f.fork(cloneRand).(S)
+}
+
+// cloneRand gives o a generator of its own that continues from the
+// current state of its source, for the built-in PCG and ChaCha8 sources.
+// Other sources cannot be copied and stay shared; so does the stateless
+// source of WithFastRand.
+func cloneRand(o *options) {
+	switch src := o.src.(type) {
+	case *rand.PCG:
+		c := *src
+		o.src = &c
+	case *rand.ChaCha8:
+		c := *src
+		o.src = &c
+	default:
+		return
+	}
+	o.rand = rand.New(o.src)
+}
+
+// diagnose returns a modifier for forked options used by diagnostics:
+// random values are drawn from a generator independent of the original,
+// and neither the shared budget nor the shared limiter is consumed.
+func diagnose() func(*options) {
+	src := rand.NewPCG(42, 1024)
+	r := rand.New(src)
+	return func(o *options) {
+		o.rand, o.src = r, src
+		o.shared = nil
+		o.limiter = nil
+	}
+}
+
+// jitterSpread returns the standard deviation of the next delay of f,
+// sampled n times on independent copies.
+func jitterSpread(f forker, n int) time.Duration {
+	mod := diagnose()
+
+	var samples []float64
+	for i := 0; i < n; i++ {
+		d, ok := f.fork(mod).Next()
+		if !ok {
+			continue
+		}
+		samples = append(samples, float64(d))
+	}
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, v := range samples {
+		mean += v
+	}
+	mean /= float64(len(samples))
+
+	var variance float64
+	for _, v := range samples {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(samples))
+
+	return time.Duration(math.Sqrt(variance))
+}
+
+// distinctDelays returns the number of unique values among the next n
+// delays of a copy of f.
+func distinctDelays(f forker, n int) int {
+	s := f.fork(diagnose())
+
+	seen := make(map[time.Duration]struct{})
+	for i := 0; i < n; i++ {
+		d, ok := s.Next()
+		if !ok {
+			break
+		}
+		seen[d] = struct{}{}
+	}
+	return len(seen)
+}
+
+// cumulativeDelay returns the sum of the first n delays of a copy of f,
+// reset to its initial state, without jitter and ignoring retry, elapsed
+// and shared budget limits, the shared limiter and the reset cooldown.
+// The sum is capped at math.MaxInt64.
+func cumulativeDelay(f forker, n int) time.Duration {
+	s := f.fork(func(o *options) {
+		o.jitter = &NoneJitter{}
+		o.maxRetries = -1
+		o.maxElapsed = 0
+		o.shared = nil
+		o.limiter = nil
+		o.resetHook = nil
+		o.resetCooldown = 0
+	})
+	s.Reset()
+
+	var total time.Duration
+	for i := 0; i < n; i++ {
+		d, ok := s.Next()
+		if !ok {
+			break
+		}
+		total = addDuration(total, d)
+	}
+	return total
+}
@@ -0,0 +1,232 @@
+package backoff
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestJitterSpread(t *testing.T) {
+	t.Run("no jitter", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0)
+		if spread := e.JitterSpread(100); spread != 0 {
+			t.Errorf("Expected zero spread without jitter, got %v", spread)
+		}
+	})
+
+	t.Run("full jitter", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(FullJitter{}))
+		e.Next()
+
+		// Uniform on [0, 200ms] has a standard deviation of about 57.7ms
+		spread := e.JitterSpread(5000)
+		if spread < 50*time.Millisecond || spread > 65*time.Millisecond {
+			t.Errorf("Expected a spread of about 58ms, got %v", spread)
+		}
+	})
+
+	t.Run("state untouched", func(t *testing.T) {
+		source := rand.NewPCG(1, 2)
+		reference := rand.NewPCG(1, 2)
+		e := NewExponential(100*time.Millisecond, 2.0,
+			WithJitterStrategy(FullJitter{}),
+			WithRandSource(source),
+			WithMaxRetries(3),
+			WithSharedBudget(NewSharedBudget(3)))
+		r := NewExponential(100*time.Millisecond, 2.0,
+			WithJitterStrategy(FullJitter{}),
+			WithRandSource(reference),
+			WithMaxRetries(3))
+
+		e.Next()
+		r.Next()
+		e.JitterSpread(100)
+
+		for i := 0; i < 2; i++ {
+			d1, ok1 := e.Next()
+			d2, ok2 := r.Next()
+			if d1 != d2 || ok1 != ok2 {
+				t.Errorf("Call %d: JitterSpread changed the state, got (%v, %v), expected (%v, %v)", i+1, d1, ok1, d2, ok2)
+			}
+		}
+	})
+
+	t.Run("all strategies", func(t *testing.T) {
+		strategies := []struct {
+			name     string
+			sequence interface{ JitterSpread(int) time.Duration }
+			jittered bool
+		}{
+			{"Constant", NewConstant(100 * time.Millisecond), false},
+			{"Exponential", NewExponential(100*time.Millisecond, 2.0, WithJitter()), true},
+			{"Decorrelated", NewDecorrelated(100*time.Millisecond, 3.0), true},
+			{"Hybrid", NewHybrid(100*time.Millisecond, 0, 0, 2.0, WithJitter()), true},
+			{"WeightedRandom", NewWeightedRandom([]WeightedDelay{{time.Second, 1}, {2 * time.Second, 1}}), true},
+		}
+
+		for _, strategy := range strategies {
+			t.Run(strategy.name, func(t *testing.T) {
+				spread := strategy.sequence.JitterSpread(100)
+				if strategy.jittered && spread == 0 {
+					t.Error("Expected a non-zero spread")
+				}
+				if !strategy.jittered && spread != 0 {
+					t.Errorf("Expected zero spread, got %v", spread)
+				}
+			})
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		c := NewConstant(time.Second, WithMaxRetries(0))
+		if spread := c.JitterSpread(10); spread != 0 {
+			t.Errorf("Expected zero spread for an exhausted sequence, got %v", spread)
+		}
+	})
+}
+
+func TestDistinctDelays(t *testing.T) {
+	t.Run("degenerate jitter", func(t *testing.T) {
+		c := NewConstant(100 * time.Millisecond)
+		if n := c.DistinctDelays(20); n != 1 {
+			t.Errorf("Expected 1 distinct delay, got %d", n)
+		}
+
+		// Full jitter of 1ns is always 1ns
+		h := NewHybrid(time.Nanosecond, 0, 100, 2.0, WithJitterStrategy(FullJitter{}))
+		if n := h.DistinctDelays(20); n != 1 {
+			t.Errorf("Expected jitter too small to bite, got %d distinct delays", n)
+		}
+	})
+
+	t.Run("varied delays", func(t *testing.T) {
+		h := NewHybrid(time.Second, 0, 100, 2.0, WithJitterStrategy(FullJitter{}))
+		if n := h.DistinctDelays(20); n < 15 {
+			t.Errorf("Expected mostly distinct delays, got %d", n)
+		}
+	})
+
+	t.Run("stops at exhaustion", func(t *testing.T) {
+		e := NewExponential(time.Millisecond, 2.0, WithMaxRetries(3))
+		if n := e.DistinctDelays(20); n != 3 {
+			t.Errorf("Expected 3 distinct delays, got %d", n)
+		}
+		if d, _ := e.Next(); d != time.Millisecond {
+			t.Errorf("Expected state untouched, got %v", d)
+		}
+	})
+}
+
+func TestCumulativeDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		sequence interface{ CumulativeDelay(int) time.Duration }
+		attempts int
+		expected time.Duration
+	}{
+		{"Constant", NewConstant(time.Second, WithMaxRetries(2)), 7, 7 * time.Second},
+		{"Exponential", NewExponential(100*time.Millisecond, 2.0, WithJitter()), 4, 1500 * time.Millisecond},
+		{"Exponential capped", NewExponential(100*time.Millisecond, 2.0, WithMaxInterval(300*time.Millisecond)), 4, 900 * time.Millisecond},
+		{"Hybrid", NewHybrid(100*time.Millisecond, 100*time.Millisecond, 2, 2.0), 4, 1500 * time.Millisecond},
+		{"Deadline", NewDeadline(time.Minute, 6), 10, time.Minute},
+		{"zero attempts", NewConstant(time.Second), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sequence.CumulativeDelay(tt.attempts); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("state untouched", func(t *testing.T) {
+		calls := 0
+		e := NewExponential(100*time.Millisecond, 2.0, WithResetHook(func() { calls++ }))
+		e.Next()
+		e.CumulativeDelay(5)
+		if d, _ := e.Next(); d != 200*time.Millisecond {
+			t.Errorf("Expected the sequence to continue at 200ms, got %v", d)
+		}
+		if calls != 0 {
+			t.Error("Expected the reset hook not to fire")
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Run("independent copy", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0, WithJitter(), WithMaxRetries(6))
+		e.Next()
+		e.Next()
+
+		c := e.Clone()
+		if c.Attempts() != 2 || c.Elapsed() != e.Elapsed() {
+			t.Fatalf("Expected the clone to carry the state, got %d attempts and %v", c.Attempts(), c.Elapsed())
+		}
+
+		// Advancing the clone leaves the original alone
+		var cloned []time.Duration
+		for d, ok := c.Next(); ok; d, ok = c.Next() {
+			cloned = append(cloned, d)
+		}
+		if e.Attempts() != 2 {
+			t.Errorf("Expected the original to keep 2 attempts, got %d", e.Attempts())
+		}
+
+		// Both continue from the same random state
+		var original []time.Duration
+		for d, ok := e.Next(); ok; d, ok = e.Next() {
+			original = append(original, d)
+		}
+		if len(cloned) != 4 || !slices.Equal(cloned, original) {
+			t.Errorf("Expected identical continuations, got %v and %v", cloned, original)
+		}
+
+		c.Reset()
+		if e.Attempts() != 6 || c.Attempts() != 0 {
+			t.Errorf("Expected Reset of the clone only, got %d and %d attempts", e.Attempts(), c.Attempts())
+		}
+	})
+
+	t.Run("all strategies", func(t *testing.T) {
+		choices := []WeightedDelay{{Delay: time.Second, Weight: 1}, {Delay: 2 * time.Second, Weight: 1}}
+		strategies := map[string]interface {
+			Sequence
+			Attempts() int
+		}{
+			"Constant":       NewConstant(time.Second).Clone(),
+			"Exponential":    NewExponential(time.Second, 2.0).Clone(),
+			"Decorrelated":   NewDecorrelated(time.Second, 3.0).Clone(),
+			"Hybrid":         NewHybrid(time.Second, time.Second, 3, 2.0).Clone(),
+			"WeightedRandom": NewWeightedRandom(choices).Clone(),
+			"Deadline":       NewDeadline(time.Minute, 5).Clone(),
+			"Pacer":          NewPacer(time.Second, time.Second).Clone(),
+			"Probing":        NewProbing(time.Second, 2.0, time.Minute, 4, time.Second).Clone(),
+		}
+		for name, s := range strategies {
+			t.Run(name, func(t *testing.T) {
+				if _, ok := s.Next(); !ok || s.Attempts() != 1 {
+					t.Errorf("Expected a working clone, got %d attempts", s.Attempts())
+				}
+			})
+		}
+	})
+
+	t.Run("random source", func(t *testing.T) {
+		for _, src := range []rand.Source{rand.NewPCG(1, 2), rand.NewChaCha8([32]byte{1})} {
+			d := NewDecorrelated(time.Second, 3.0, WithRandSource(src))
+			d.Next()
+			c := d.Clone()
+			if c.options.rand == d.options.rand {
+				t.Errorf("%T: expected the clone to get its own generator", src)
+			}
+			a, _ := d.Next()
+			b, _ := c.Next()
+			if a != b {
+				t.Errorf("%T: expected the clone to continue from the same state, got %v and %v", src, a, b)
+			}
+		}
+	})
+}
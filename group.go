@@ -0,0 +1,113 @@
+package backoff
+
+import (
+	"context"
+	"sync"
+)
+
+// RetryGroup runs operations concurrently, retrying each one with its own
+// sequence, while capping how many of them run at once. It combines the
+// retry helpers with an errgroup-style bounded fan-out.
+//
+// The first operation that fails for good, i.e. after its sequence is
+// exhausted or with an error rejected by RetryIf, cancels the group's
+// context, which stops the other operations at their next wait. Wait
+// returns that first error.
+//
+// A RetryGroup must not be reused after Wait returns.
+type RetryGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	newSeq func() Sequence
+	sem    chan struct{} // nil = no concurrency limit
+	opts   []RetryOption
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+// NewRetryGroup creates a new retry group derived from ctx.
+//
+// Parameters:
+//   - ctx: Cancelling ctx stops all operations of the group
+//   - newSeq: Returns a fresh sequence for each operation
+//   - limit: Maximum number of operations running at once (<= 0 = unlimited)
+//   - opts: Retry options applied to every operation
+//
+// Example:
+//
+//	g := NewRetryGroup(ctx, func() Sequence {
+//		return NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(5))
+//	}, 8)
+//	for _, item := range items {
+//		g.Go(func() error { return upload(item) })
+//	}
+//	if err := g.Wait(); err != nil {
+//		log.Printf("upload failed: %v", err)
+//	}
+func NewRetryGroup(ctx context.Context, newSeq func() Sequence, limit int, opts ...RetryOption) *RetryGroup {
+	ctx, cancel := context.WithCancel(ctx)
+
+	g := &RetryGroup{
+		ctx:    ctx,
+		cancel: cancel,
+		newSeq: newSeq,
+		opts:   opts,
+	}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+
+	return g
+}
+
+// Go retries op in a new goroutine until it succeeds, its sequence is
+// exhausted, or the group's context is cancelled.
+//
+// If the concurrency limit is reached, Go blocks until a running operation
+// finishes. If the group's context is cancelled while waiting, op is not
+// run and the context error is recorded.
+func (g *RetryGroup) Go(op func() error) {
+	g.wg.Add(1)
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.fail(g.ctx.Err())
+			g.wg.Done()
+			return
+		}
+	}
+
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		err := RetryWithContext(g.ctx, g.newSeq(), func(context.Context) error {
+			return op()
+		}, g.opts...)
+		if err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until all operations started with Go have returned, then
+// returns the first error, if any.
+func (g *RetryGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// fail records err if it is the first error and cancels the group.
+func (g *RetryGroup) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
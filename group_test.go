@@ -0,0 +1,98 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryGroup(t *testing.T) {
+	errFail := errors.New("fail")
+	newSeq := func() Sequence {
+		return NewConstant(time.Millisecond, WithMaxRetries(3))
+	}
+
+	t.Run("retries each operation", func(t *testing.T) {
+		g := NewRetryGroup(context.Background(), newSeq, 2)
+
+		var calls atomic.Int32
+		for i := 0; i < 5; i++ {
+			var n int
+			g.Go(func() error {
+				calls.Add(1)
+				if n++; n < 3 {
+					return errFail
+				}
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+		if got := calls.Load(); got != 15 {
+			t.Errorf("Expected 15 calls (5 ops x 3 attempts), got %d", got)
+		}
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		const limit = 3
+		g := NewRetryGroup(context.Background(), newSeq, limit)
+
+		var running, peak atomic.Int32
+		for i := 0; i < 10; i++ {
+			g.Go(func() error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+		if p := peak.Load(); p > limit {
+			t.Errorf("Expected at most %d concurrent operations, got %d", limit, p)
+		}
+	})
+
+	t.Run("returns first error", func(t *testing.T) {
+		g := NewRetryGroup(context.Background(), newSeq, 0)
+
+		var calls atomic.Int32
+		g.Go(func() error {
+			calls.Add(1)
+			return errFail
+		})
+		if err := g.Wait(); !errors.Is(err, errFail) {
+			t.Errorf("Expected %v, got %v", errFail, err)
+		}
+		if got := calls.Load(); got != 4 {
+			t.Errorf("Expected 4 calls (1 + 3 retries), got %d", got)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		g := NewRetryGroup(ctx, func() Sequence { return NewConstant(time.Hour) }, 1)
+
+		g.Go(func() error {
+			cancel()
+			return errFail
+		})
+		g.Go(func() error {
+			t.Error("op should not run after the context is cancelled")
+			return nil
+		})
+		if err := g.Wait(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"math"
+	"time"
+)
+
+// Hybrid implements a two-phase backoff strategy: delays grow linearly for
+// the first attempts and exponentially afterwards.
+//
+// This is useful when early failures are likely transient and should be
+// retried gently, while a persisting failure is probably a real outage
+// that calls for backing off aggressively.
+type Hybrid struct {
+	options   *options
+	base      time.Duration // initial delay duration
+	increment time.Duration // linear increment per retry
+	switchAt  int           // retry index at which growth becomes exponential
+	factor    float64       // multiplier for each retry after switchAt
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	current time.Duration // un-jittered delay of the last retry
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewHybrid creates a new linear-then-exponential backoff strategy.
+//
+// Parameters:
+//   - base: The initial delay duration for the first retry
+//   - increment: The duration added to the delay for each retry before switchAt
+//   - switchAt: The retry index (0-based) from which delays grow exponentially
+//   - factor: The multiplier applied after switchAt (must be > 1.0)
+//   - opts: Optional configuration functions
+//
+// If factor <= 1.0, it defaults to 2.0. A negative switchAt is treated as 0,
+// which makes the strategy purely exponential.
+//
+// Example:
+//
+//	// 100ms, 200ms, 300ms, then 600ms, 1.2s, 2.4s, ...
+//	h := NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0,
+//		WithMaxInterval(30*time.Second))
+func NewHybrid(base, increment time.Duration, switchAt int, factor float64, opts ...Option) *Hybrid {
+	if factor <= 1.0 {
+		factor = 2.0
+	}
+	if switchAt < 0 {
+		switchAt = 0
+	}
+
+	o := applyOptions(opts)
+
+	h := &Hybrid{
+		options:   o,
+		base:      base,
+		increment: increment,
+		switchAt:  switchAt,
+		factor:    factor,
+		elapsed:   o.elapsedOffset,
+	}
+	h.skip(o.startSkip())
+
+	return h
+}
+
+// Next returns the next delay duration. Before switchAt the delay is
+// base + increment*retry; from switchAt on, the previous delay is
+// multiplied by factor.
+//
+// Growth is computed on the un-jittered delay. The returned delay is
+// subject to jitter, min/max bounds and overflow protection.
+//
+// Returns:
+//   - time.Duration: The calculated delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+func (h *Hybrid) Next() (time.Duration, bool) {
+	d, _, ok := h.NextDetailed()
+	return d, ok
+}
+
+// NextContext is like Next, but returns (0, false) without growing the
+// delay if ctx is done.
+func (h *Hybrid) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, h)
+}
+
+// NextDetailed is like Next, but also returns the raw delay, the linear or
+// geometric value before jitter, bounds and scaling are applied. Comparing
+// it with the result shows why a delay was clamped or jittered to a
+// surprising value.
+//
+// Returns (0, 0, false) if limits are reached.
+func (h *Hybrid) NextDetailed() (result time.Duration, raw time.Duration, ok bool) {
+	if h.options.outOfRetries(h.retries) {
+		h.last, h.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	next := h.step(h.retries, h.current)
+	d := h.options.applyJitter(next, h.elapsed)
+	d = applyBounds(d, h.options.minInterval, h.options.maxAt(h.retries))
+	d = h.options.firstRange(d, h.retries)
+	d = h.options.scaled(d)
+	if h.options.maxElapsed > 0 && !h.options.free(h.retries) && h.options.spent(h.elapsed)+d >= h.options.maxElapsed {
+		h.last, h.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	if !h.options.acquire() {
+		h.last, h.hasLast = 0, false
+		return 0, 0, false
+	}
+
+	d = h.options.finish(d)
+	h.current = next
+	h.elapsed += h.options.charge(h.retries, d)
+	h.retries++
+	h.last, h.hasLast = d, true
+	return d, next, true
+}
+
+// skip advances the growth state by n steps, as WithRandomStart does at
+// construction.
+func (h *Hybrid) skip(n int) {
+	for ; n > 0; n-- {
+		h.current = h.step(h.retries, h.current)
+		h.retries++
+	}
+}
+
+// DelayAt returns the delay the strategy would produce for the given
+// attempt (0-based) without jitter, without modifying its state. The
+// delay is subject to min/max bounds and overflow protection, but
+// ignores runtime scaling and elapsed limits.
+func (h *Hybrid) DelayAt(attempt int) time.Duration {
+	var current time.Duration
+	for i := 0; i <= attempt; i++ {
+		next := h.step(i, current)
+		if i >= h.switchAt && next == current {
+			break // reached a fixed point, e.g. math.MaxInt64
+		}
+		current = next
+	}
+	current = applyBounds(current, h.options.minInterval, h.options.maxAt(attempt))
+	return h.options.firstRange(current, attempt)
+}
+
+// JitterSpread returns the standard deviation of the next delay of h; see
+// Common methods.
+func (h *Hybrid) JitterSpread(n int) time.Duration {
+	return jitterSpread(h, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of h; see Common methods.
+func (h *Hybrid) DistinctDelays(n int) int {
+	return distinctDelays(h, n)
+}
+
+// CumulativeDelay returns the sum of the first attempts delays without
+// jitter, across both phases and ignoring the configured limits. Real runs
+// with jitter vary around this value.
+func (h *Hybrid) CumulativeDelay(attempts int) time.Duration {
+	return cumulativeDelay(h, attempts)
+}
+
+// fork returns a copy of the hybrid backoff including its state.
+func (h *Hybrid) fork(mod func(*options)) Sequence {
+	f := *h
+	f.options = forkOptions(h.options, mod)
+	return &f
+}
+
+// step returns the un-jittered delay for the given retry index, where
+// current is the un-jittered delay of the previous retry. The result is
+// capped at math.MaxInt64.
+func (h *Hybrid) step(retry int, current time.Duration) time.Duration {
+	if retry > 0 && retry >= h.switchAt {
+		return mulDuration(current, h.factor)
+	}
+
+	raw := float64(h.base) + float64(h.increment)*float64(retry)
+	if raw >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(raw)
+}
+
+// Reset resets the hybrid backoff to its initial state.
+// This clears the retry count, current delay calculation and the delay
+// reported by Current, and restores the elapsed time to its initial offset.
+func (h *Hybrid) Reset() {
+	if !h.options.allowReset() {
+		return
+	}
+	h.retries = 0
+	h.elapsed = h.options.elapsedOffset
+	h.current = 0
+	h.last = 0
+	h.hasLast = false
+	h.options.reset()
+}
+
+// SoftReset rewinds the sequence by half of its retries, rounded up: the
+// retry count is halved and the un-jittered delay is recalculated for the
+// kept retries, so the next delay is exactly the one that followed them.
+// The delay reported by Current is cleared. Unlike Reset, the elapsed time
+// is kept.
+func (h *Hybrid) SoftReset() {
+	h.retries /= 2
+	h.current = 0
+	for i := 0; i < h.retries; i++ {
+		h.current = h.step(i, h.current)
+	}
+	h.last = 0
+	h.hasLast = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (h *Hybrid) Current() (time.Duration, bool) {
+	return h.last, h.hasLast
+}
+
+// Attempts returns the number of delays returned since construction or
+// Reset, less those rewound by SoftReset.
+func (h *Hybrid) Attempts() int {
+	return h.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (h *Hybrid) Elapsed() time.Duration {
+	return h.elapsed
+}
+
+// RemainingRetries returns the retries h has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (h *Hybrid) RemainingRetries() int {
+	return remainingRetries(h.options, h.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (h *Hybrid) budget() (limit, spent time.Duration) {
+	return h.options.maxElapsed, h.options.spent(h.elapsed)
+}
+
+// Remaining returns the elapsed budget h has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (h *Hybrid) Remaining() time.Duration {
+	return remaining(h)
+}
+
+// Derive returns a hybrid backoff that starts over in the linear phase,
+// but is charged with the elapsed time of h, so that a nested operation
+// stops at the same deadline.
+func (h *Hybrid) Derive() *Hybrid {
+	return derive(h, h)
+}
+
+// Clone returns an independent copy of h, including the current delay and
+// phase.
+func (h *Hybrid) Clone() *Hybrid {
+	return clone(h)
+}
+
+// Iter returns an iterator over the next delays of h, see Iterate.
+func (h *Hybrid) Iter() iter.Seq[time.Duration] {
+	return Iterate(h)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of h ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (h *Hybrid) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, h, h.options, now)
+}
+
+// Describe returns the configuration of the hybrid backoff.
+func (h *Hybrid) Describe() Description {
+	return h.options.describe(Description{
+		Kind:      KindHybrid,
+		Base:      h.base,
+		Factor:    h.factor,
+		Increment: h.increment,
+		SwitchAt:  h.switchAt,
+	})
+}
+
+// WithOverrides returns a new hybrid backoff with the same base,
+// increment, switch point, factor and options, plus opts applied on top,
+// starting from its initial state.
+func (h *Hybrid) WithOverrides(opts ...Option) Sequence {
+	return NewHybrid(h.base, h.increment, h.switchAt, h.factor, h.options.with(opts)...)
+}
@@ -0,0 +1,118 @@
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHybrid(t *testing.T) {
+	t.Run("linear then exponential", func(t *testing.T) {
+		h := NewHybrid(100*time.Millisecond, 100*time.Millisecond, 3, 2.0)
+
+		expected := []time.Duration{
+			100 * time.Millisecond,  // base
+			200 * time.Millisecond,  // base + increment
+			300 * time.Millisecond,  // base + 2*increment
+			600 * time.Millisecond,  // previous * 2
+			1200 * time.Millisecond, // previous * 2
+		}
+
+		for i, exp := range expected {
+			d, ok := h.Next()
+			if !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+			if d != exp {
+				t.Errorf("Call %d: expected %v, got %v", i+1, exp, d)
+			}
+		}
+	})
+
+	t.Run("switch at zero is exponential", func(t *testing.T) {
+		h := NewHybrid(10*time.Millisecond, time.Second, 0, 3.0)
+
+		for i, exp := range []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 90 * time.Millisecond} {
+			d, _ := h.Next()
+			if d != exp {
+				t.Errorf("Call %d: expected %v, got %v", i+1, exp, d)
+			}
+		}
+	})
+
+	t.Run("factor validation", func(t *testing.T) {
+		h := NewHybrid(10*time.Millisecond, 0, -1, 0.5)
+		if h.factor != 2.0 {
+			t.Errorf("Expected factor to default to 2.0, got %v", h.factor)
+		}
+		if h.switchAt != 0 {
+			t.Errorf("Expected negative switchAt to become 0, got %d", h.switchAt)
+		}
+	})
+
+	t.Run("with max interval", func(t *testing.T) {
+		maxInterval := 500 * time.Millisecond
+		h := NewHybrid(100*time.Millisecond, 100*time.Millisecond, 2, 4.0,
+			WithMaxInterval(maxInterval))
+
+		for i := 0; i < 6; i++ {
+			d, _ := h.Next()
+			if d > maxInterval {
+				t.Errorf("Call %d: %v exceeds max interval %v", i+1, d, maxInterval)
+			}
+		}
+	})
+
+	t.Run("with max retries", func(t *testing.T) {
+		h := NewHybrid(time.Millisecond, time.Millisecond, 2, 2.0, WithMaxRetries(3))
+
+		for i := 0; i < 3; i++ {
+			if _, ok := h.Next(); !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+		}
+		if d, ok := h.Next(); ok || d != 0 {
+			t.Errorf("Expected (0, false) after max retries, got (%v, %v)", d, ok)
+		}
+	})
+
+	t.Run("overflow protection", func(t *testing.T) {
+		h := NewHybrid(time.Duration(1<<60), time.Duration(1<<60), 1, 1000.0)
+
+		h.Next()
+		d, ok := h.Next()
+		if !ok {
+			t.Fatal("Second call should succeed")
+		}
+		if d != time.Duration(math.MaxInt64) {
+			t.Errorf("Expected delay capped at %v, got %v", time.Duration(math.MaxInt64), d)
+		}
+	})
+
+	t.Run("reset functionality", func(t *testing.T) {
+		h := NewHybrid(10*time.Millisecond, 10*time.Millisecond, 1, 2.0)
+		h.Next()
+		h.Next()
+		h.Next()
+
+		h.Reset()
+		d, _ := h.Next()
+		if d != 10*time.Millisecond {
+			t.Errorf("After reset, expected %v, got %v", 10*time.Millisecond, d)
+		}
+	})
+
+	t.Run("soft reset", func(t *testing.T) {
+		h := NewHybrid(100*time.Millisecond, 100*time.Millisecond, 2, 2.0, WithJitter())
+		for i := 0; i < 5; i++ {
+			h.Next()
+		}
+
+		// Rewinds to retry 2, whose un-jittered delay is 400ms
+		h.SoftReset()
+		h.options.jitter = &NoneJitter{}
+		if d, _ := h.Next(); d != 400*time.Millisecond {
+			t.Errorf("After soft reset, expected %v, got %v", 400*time.Millisecond, d)
+		}
+	})
+}
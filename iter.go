@@ -0,0 +1,57 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// iterSchedule returns an iterator over the delays of s and the absolute
+// times at which they end, counted from now or, if now is zero, from the
+// current time of the strategy's Clock. The delays come from
+// s.NextContext(ctx), so the iteration advances s like a retry loop would,
+// without sleeping.
+func iterSchedule(ctx context.Context, s ContextSequence, o *options, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return func(yield func(time.Duration, time.Time) bool) {
+		at := now
+		if at.IsZero() {
+			at = o.clock.Now()
+		}
+
+		for {
+			d, ok := s.NextContext(ctx)
+			if !ok {
+				return
+			}
+			at = at.Add(d)
+			if !yield(d, at) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate returns an iterator over the delays of s, calling s.Next() for
+// each one until it returns false, e.g.:
+//
+//	for d := range Iterate(b) {
+//		time.Sleep(d)
+//		if try() == nil {
+//			break
+//		}
+//	}
+//
+// Breaking out of the loop stops the iteration cleanly; s is left at the
+// position reached. Nothing sleeps. The iterator can be ranged over again
+// to continue where the last loop stopped, and after s.Reset() to start
+// over. For a Sequence that never ends, the loop must break on its own.
+func Iterate(s Sequence) iter.Seq[time.Duration] {
+	return func(yield func(time.Duration) bool) {
+		for {
+			d, ok := s.Next()
+			if !ok || !yield(d) {
+				return
+			}
+		}
+	}
+}
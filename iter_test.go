@@ -0,0 +1,106 @@
+package backoff
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIterSchedule(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("delays and fire times", func(t *testing.T) {
+		e := NewExponential(time.Second, 2.0, WithMaxRetries(3))
+
+		wantDelays := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+		wantTimes := []time.Time{now.Add(time.Second), now.Add(3 * time.Second), now.Add(7 * time.Second)}
+		i := 0
+		for d, at := range e.IterSchedule(context.Background(), now) {
+			if d != wantDelays[i] || !at.Equal(wantTimes[i]) {
+				t.Errorf("Step %d: expected (%v, %v), got (%v, %v)", i, wantDelays[i], wantTimes[i], d, at)
+			}
+			i++
+		}
+		if i != 3 {
+			t.Errorf("Expected 3 steps until exhaustion, got %d", i)
+		}
+	})
+
+	t.Run("uses the clock without now", func(t *testing.T) {
+		clock := &fakeClock{now: now}
+		c := NewConstant(time.Second, WithClock(clock), WithMaxRetries(1))
+		for _, at := range c.IterSchedule(context.Background(), time.Time{}) {
+			if !at.Equal(now.Add(time.Second)) {
+				t.Errorf("Expected %v, got %v", now.Add(time.Second), at)
+			}
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		steps := 0
+		for range NewConstant(time.Second).IterSchedule(ctx, now) {
+			if steps++; steps == 5 {
+				cancel()
+			}
+		}
+		if steps != 5 {
+			t.Errorf("Expected 5 steps before cancellation, got %d", steps)
+		}
+	})
+
+	t.Run("shares state with Next", func(t *testing.T) {
+		e := NewExponential(time.Second, 2.0)
+		for range e.IterSchedule(context.Background(), now) {
+			break
+		}
+		if d, _ := e.Next(); d != 2*time.Second {
+			t.Errorf("Expected iteration to advance the sequence, got %v", d)
+		}
+	})
+}
+
+func TestIterate(t *testing.T) {
+	t.Run("first N delays", func(t *testing.T) {
+		e := NewExponential(100*time.Millisecond, 2.0)
+
+		want := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			1600 * time.Millisecond,
+		}
+		var got []time.Duration
+		for d := range e.Iter() {
+			got = append(got, d)
+			if len(got) == len(want) {
+				break
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+
+		// Breaking early leaves the sequence where it stopped
+		if e.Attempts() != len(want) {
+			t.Errorf("Expected %d attempts after break, got %d", len(want), e.Attempts())
+		}
+		for d := range e.Iter() {
+			if d != 3200*time.Millisecond {
+				t.Errorf("Expected to continue with 3.2s, got %v", d)
+			}
+			break
+		}
+	})
+
+	t.Run("stops when exhausted", func(t *testing.T) {
+		got := slices.Collect(Iterate(NewConstant(time.Second, WithMaxRetries(3))))
+		if want := []time.Duration{time.Second, time.Second, time.Second}; !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+}
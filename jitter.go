@@ -1,6 +1,8 @@
 package backoff
 
 import (
+	"fmt"
+	"math"
 	"math/rand/v2"
 	"time"
 )
@@ -12,9 +14,49 @@ import (
 type Jitter interface {
 	// Apply takes a calculated delay duration and applies jitter using the
 	// provided random number generator, returning the final delay to use.
+	// The strategies cap the result at maxInterval afterwards, so Apply may
+	// return a value larger than d.
 	Apply(d time.Duration, r *rand.Rand) time.Duration
 }
 
+// JitterFunc adapts an ordinary function to the Jitter interface, like
+// http.HandlerFunc, for one-off strategies that do not need a type of
+// their own.
+//
+// Example:
+//
+//	// Between 90% and 100% of the delay
+//	j := JitterFunc(func(d time.Duration, r *rand.Rand) time.Duration {
+//		f, ok := RandFloat(r)
+//		if !ok {
+//			return d
+//		}
+//		return d - time.Duration(float64(d)*0.1*f)
+//	})
+//	b := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(j))
+type JitterFunc func(d time.Duration, r *rand.Rand) time.Duration
+
+// Apply returns f(d, r).
+func (f JitterFunc) Apply(d time.Duration, r *rand.Rand) time.Duration {
+	return f(d, r)
+}
+
+// ElapsedAwareJitter is a Jitter whose spread depends on how long the
+// strategy has been retrying, not only on the current delay.
+//
+// The strategies check for this interface with a type assertion on every
+// Next() and, if the configured jitter implements it, call ApplyWithElapsed
+// with the elapsed time as compared against WithMaxElapsed: the sum of the
+// delays returned so far, or the wall-clock time with WithStartTime.
+// Otherwise they fall back to the plain Apply, so existing jitters are
+// unaffected.
+type ElapsedAwareJitter interface {
+	Jitter
+
+	// ApplyWithElapsed is like Apply, with the elapsed time so far.
+	ApplyWithElapsed(d, elapsed time.Duration, r *rand.Rand) time.Duration
+}
+
 // NoneJitter implements a jitter strategy that applies no randomization.
 // The delay duration is returned unchanged. This is the default jitter
 // strategy when no jitter options are specified.
@@ -25,22 +67,65 @@ func (nj *NoneJitter) Apply(d time.Duration, _ *rand.Rand) time.Duration {
 	return d
 }
 
+// String returns "none".
+func (NoneJitter) String() string { return "none" }
+
 // FullJitter implements a jitter strategy that randomizes the entire delay.
 // The final delay is a random value between 1 and the calculated delay duration.
 // This provides maximum randomization but may result in very short delays.
 //
+// The range is [1ns, d], uniformly distributed, so a positive delay never
+// becomes 0. For large delays the 1ns shift is negligible, but for tiny
+// ones it is a real bias; use FullJitterFromZero for the range [0, d].
+//
 // Formula: random(1, calculated_delay)
 type FullJitter struct{}
 
 // Apply returns a random duration between 1 and the input duration (inclusive).
-// If the input duration is <= 0, returns 0.
+// If the input duration is <= 0, returns 0. If the random number generator
+// fails, the input duration is returned unchanged.
 func (FullJitter) Apply(d time.Duration, r *rand.Rand) time.Duration {
 	if d <= 0 {
 		return 0
 	}
-	return time.Duration(r.Int64N(int64(d)) + 1)
+	v, ok := randInt64N(r, int64(d))
+	if !ok {
+		return d
+	}
+	return time.Duration(v + 1)
+}
+
+// String returns "full".
+func (FullJitter) String() string { return "full" }
+
+// FullJitterFromZero implements full jitter over the range [0, d], the
+// textbook definition, uniformly distributed. Unlike FullJitter, a delay
+// may become 0, which means retrying immediately.
+//
+// Formula: random(0, calculated_delay)
+type FullJitterFromZero struct{}
+
+// Apply returns a random duration between 0 and the input duration
+// (inclusive). If the input duration is <= 0, returns 0. If the random
+// number generator fails, the input duration is returned unchanged.
+func (FullJitterFromZero) Apply(d time.Duration, r *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	n := int64(d)
+	if n < math.MaxInt64 {
+		n++
+	}
+	v, ok := randInt64N(r, n)
+	if !ok {
+		return d
+	}
+	return time.Duration(v)
 }
 
+// String returns "full-from-zero".
+func (FullJitterFromZero) String() string { return "full-from-zero" }
+
 // EqualJitter implements a jitter strategy that uses half the calculated delay
 // as a base and adds randomness to the other half. This provides a good balance
 // between maintaining reasonable delay lengths and adding randomization.
@@ -50,11 +135,363 @@ type EqualJitter struct{}
 
 // Apply returns half the input duration plus a random amount up to the other half.
 // This ensures the result is between 50% and 100% of the original duration.
-// If the input duration is <= 0, returns 0.
+// If the input duration is <= 0, returns 0. If the random number generator
+// fails, the input duration is returned unchanged.
 func (EqualJitter) Apply(d time.Duration, r *rand.Rand) time.Duration {
 	if d <= 0 {
 		return 0
 	}
 	half := d / 2
-	return half + time.Duration(r.Int64N(int64(d-half)+1))
+	v, ok := randInt64N(r, int64(d-half)+1)
+	if !ok {
+		return d
+	}
+	return half + time.Duration(v)
+}
+
+// String returns "equal".
+func (EqualJitter) String() string { return "equal" }
+
+// fixedJitter replaces randomization with a fixed fraction of the delay.
+// It backs WithDeterministicJitter and is not exported, so that it cannot be
+// mistaken for a production jitter strategy.
+type fixedJitter struct {
+	fraction float64
+}
+
+// Apply returns d * fraction, capped at math.MaxInt64. The random number
+// generator is not used.
+func (j fixedJitter) Apply(d time.Duration, _ *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	v := float64(d) * j.fraction
+	if v >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(v)
+}
+
+// String returns "deterministic".
+func (fixedJitter) String() string { return "deterministic" }
+
+// ProportionalJitter implements a jitter strategy that spreads the final
+// delay by ±Fraction of the calculated delay, uniformly distributed, e.g.
+// ±20% for a Fraction of 0.2. Unlike EqualJitter, the delay is as likely
+// to grow as to shrink, so the average delay stays the calculated one.
+//
+// The fraction is clamped to [0, 1]; NaN means no jitter.
+//
+// Formula: random(calculated_delay*(1-Fraction), calculated_delay*(1+Fraction))
+type ProportionalJitter struct {
+	Fraction float64 // spread as a fraction of the delay, in [0, 1]
+}
+
+// Apply returns a random duration within Fraction of the input duration,
+// in either direction, capped at math.MaxInt64. If the input duration is
+// <= 0, returns 0. If the random number generator fails, the input
+// duration is returned unchanged.
+func (j ProportionalJitter) Apply(d time.Duration, r *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	f := j.Fraction
+	if math.IsNaN(f) {
+		f = 0
+	}
+	f = min(max(f, 0), 1)
+
+	spread := time.Duration(float64(d) * f)
+	return spreadAround(r, d, d-spread, addDuration(d, spread))
+}
+
+// String returns "proportional". The fraction is not part of the name.
+func (ProportionalJitter) String() string { return "proportional" }
+
+// BetaJitter implements a jitter strategy that samples the final delay from
+// a Beta(Alpha, Beta) distribution scaled to [0, calculated_delay]. The shape
+// parameters control where in the window delays tend to land:
+//   - Alpha = Beta = 1: uniform, like FullJitter
+//   - Alpha > Beta: skewed towards the calculated delay (late retries)
+//   - Alpha < Beta: skewed towards zero (early retries)
+//
+// Parameters that are not finite and positive, including NaN, default to 1.
+//
+// Formula: calculated_delay * Beta(Alpha, Beta)
+type BetaJitter struct {
+	Alpha float64 // shape parameter, must be > 0
+	Beta  float64 // shape parameter, must be > 0
+}
+
+// Apply returns the input duration scaled by a Beta distributed sample.
+// The mean of the result is d * Alpha / (Alpha + Beta).
+// If the input duration is <= 0, returns 0. If the random number generator
+// fails, the input duration is returned unchanged.
+func (bj BetaJitter) Apply(d time.Duration, r *rand.Rand) (result time.Duration) {
+	if d <= 0 {
+		return 0
+	}
+	defer func() {
+		if recover() != nil {
+			result = d
+		}
+	}()
+
+	a, b := betaShape(bj.Alpha), betaShape(bj.Beta)
+
+	x, ok := randGamma(r, a)
+	if !ok {
+		return d
+	}
+	y, ok := randGamma(r, b)
+	if !ok {
+		return d
+	}
+	if x+y == 0 {
+		return 0
+	}
+	return time.Duration(float64(d) * x / (x + y))
+}
+
+// String returns "beta". The shape parameters are not part of the name.
+func (BetaJitter) String() string { return "beta" }
+
+// ElapsedProportionalJitter implements an elapsed-aware jitter strategy
+// whose spread grows with the time spent retrying: the final delay is
+// picked uniformly within Fraction * elapsed of the calculated delay, in
+// either direction, and never below 0. Early on the delays are barely
+// jittered; during a long outage they spread out more and more.
+//
+// Combine it with WithMaxInterval to bound the spread from above.
+//
+// Formula: calculated_delay + random(-Fraction*elapsed, Fraction*elapsed)
+type ElapsedProportionalJitter struct {
+	Fraction float64 // spread as a fraction of the elapsed time
+}
+
+// Apply returns d unchanged, since there is no elapsed time to scale by.
+func (ElapsedProportionalJitter) Apply(d time.Duration, _ *rand.Rand) time.Duration {
+	return d
+}
+
+// ApplyWithElapsed returns a random duration within Fraction * elapsed of
+// d, clamped to non-negative values. If the random number generator
+// fails, d is returned unchanged.
+func (j ElapsedProportionalJitter) ApplyWithElapsed(d, elapsed time.Duration, r *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	spread := float64(elapsed) * j.Fraction
+	if spread <= 0 || math.IsNaN(spread) {
+		return d
+	}
+	s := time.Duration(min(spread, float64(math.MaxInt64/2)))
+	return spreadAround(r, d, max(d-s, 0), addDuration(d, s))
+}
+
+// String returns "elapsed-proportional".
+func (ElapsedProportionalJitter) String() string { return "elapsed-proportional" }
+
+// SlottedJitter implements a deterministic jitter strategy for a fleet of
+// a known number of clients: each client is assigned its own slot of the
+// delay window, so that no two clients retry at the same time. For a fixed
+// fleet, this decorrelates the retries perfectly, where random jitter only
+// does so on average.
+//
+// The slots are evenly spaced: client Index of Total waits Index/Total of
+// the calculated delay, i.e. client 0 retries immediately and the last
+// client just before the calculated delay. The result depends only on
+// Index and Total; the random number generator is not used. Index is taken
+// modulo Total. If Total <= 0, the delay is returned unchanged.
+//
+// Formula: calculated_delay * Index / Total
+type SlottedJitter struct {
+	Index int // index of this client, in [0, Total)
+	Total int // number of clients in the fleet
+}
+
+// Apply returns the start of the client's slot of the input duration.
+// If the input duration is <= 0, returns 0.
+func (j SlottedJitter) Apply(d time.Duration, _ *rand.Rand) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	if j.Total <= 0 {
+		return d
+	}
+
+	n := time.Duration(j.Total)
+	i := time.Duration(j.Index) % n
+	if i < 0 {
+		i += n
+	}
+	// d * i / n without overflow
+	return d/n*i + d%n*i/n
+}
+
+// String returns "slotted". The index and total are not part of the name.
+func (SlottedJitter) String() string { return "slotted" }
+
+// builtinJitters lists the jitter strategies that can be selected by name.
+// Their String methods are the single source of the names.
+var builtinJitters = []Jitter{
+	&NoneJitter{},
+	FullJitter{},
+	FullJitterFromZero{},
+	EqualJitter{},
+	ProportionalJitter{},
+	BetaJitter{},
+	ElapsedProportionalJitter{},
+	SlottedJitter{},
+	fixedJitter{},
+	&AdaptiveJitter{},
+}
+
+// JitterByName returns the built-in jitter strategy with the given name,
+// as returned by its String method: "none", "full", "full-from-zero",
+// "equal", "proportional", "beta", "elapsed-proportional", "slotted",
+// "adaptive", or "deterministic", the jitter set by
+// WithDeterministicJitter.
+// This supports selecting jitter from configuration. Parameterized
+// strategies are returned with their zero parameters; use JitterSpec to
+// configure them. Each call returns a new AdaptiveJitter, since it holds
+// state.
+//
+// Returns an error for unknown names.
+//
+// Example:
+//
+//	j, err := JitterByName(cfg.Jitter)
+//	if err != nil {
+//		return err
+//	}
+//	b := NewExponential(100*time.Millisecond, 2.0, WithJitterStrategy(j))
+func JitterByName(name string) (Jitter, error) {
+	for _, j := range builtinJitters {
+		if j.(fmt.Stringer).String() == name {
+			if _, ok := j.(*AdaptiveJitter); ok {
+				return NewAdaptiveJitter(0, 0, 0), nil // stateful, never shared
+			}
+			return j, nil
+		}
+	}
+	return nil, fmt.Errorf("backoff: unknown jitter %q", name)
+}
+
+// spreadAround returns a random duration in [low, high], which contains d.
+// Unlike randBetween, it falls back to d if the random number generator
+// fails, as every jitter does.
+func spreadAround(r *rand.Rand, d, low, high time.Duration) time.Duration {
+	if high <= low {
+		return d
+	}
+	v, ok := randInt64N(r, int64(high-low)+1)
+	if !ok {
+		return d
+	}
+	return low + time.Duration(v)
+}
+
+// betaShape returns p if it is a usable Beta shape parameter, otherwise 1.
+func betaShape(p float64) float64 {
+	if !(p > 0) || math.IsInf(p, 0) {
+		return 1
+	}
+	return p
+}
+
+// maxGammaRejections caps the rejection loop in randGamma. The acceptance
+// rate is above 95% for every shape, so the cap is only reached if the
+// random source is degenerate.
+const maxGammaRejections = 1000
+
+// randGamma returns a Gamma(shape, 1) distributed sample using the
+// Marsaglia-Tsang method. Shapes below 1 are boosted by one and corrected
+// with a uniform power. Returns ok=false if the random number generator
+// fails. If no sample is accepted within maxGammaRejections tries, the
+// approximate mode of the distribution is returned instead.
+func randGamma(r *rand.Rand, shape float64) (float64, bool) {
+	if shape < 1 {
+		g, ok := randGamma(r, shape+1)
+		u, ok2 := RandFloat(r)
+		return g * math.Pow(u, 1/shape), ok && ok2
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for i := 0; i < maxGammaRejections; i++ {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u, ok := RandFloat(r)
+		if !ok {
+			return 0, false
+		}
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v, true
+		}
+	}
+	return d, true
+}
+
+// randInt64N returns a random value in [0, n) drawn from r. Instead of
+// panicking, it returns ok=false if n <= 0, r is nil, or r panics, e.g.
+// because a custom rand.Source misbehaves. This keeps a bad random source
+// from crashing a retry loop.
+func randInt64N(r *rand.Rand, n int64) (v int64, ok bool) {
+	if n <= 0 || r == nil {
+		return 0, false
+	}
+	defer func() {
+		if recover() != nil {
+			v, ok = 0, false
+		}
+	}()
+	return r.Int64N(n), true
+}
+
+// randUint64N is like randInt64N for uint64 ranges. n == 0 is not a valid
+// range.
+func randUint64N(r *rand.Rand, n uint64) (v uint64, ok bool) {
+	if n == 0 || r == nil {
+		return 0, false
+	}
+	defer func() {
+		if recover() != nil {
+			v, ok = 0, false
+		}
+	}()
+	return r.Uint64N(n), true
+}
+
+// RandFloat returns a uniform random value in [0, 1) drawn from r. Like the
+// built-in jitter strategies, custom Jitter implementations can use it to
+// get floats without reimplementing the conversion or the error handling.
+//
+// Instead of panicking, it returns ok=false if r is nil or r panics, e.g.
+// because a custom rand.Source misbehaves. Jitter implementations should
+// then return the delay unchanged.
+//
+// Example:
+//
+//	func (j MyJitter) Apply(d time.Duration, r *rand.Rand) time.Duration {
+//		f, ok := backoff.RandFloat(r)
+//		if !ok {
+//			return d
+//		}
+//		return time.Duration(float64(d) * (0.8 + 0.4*f))
+//	}
+func RandFloat(r *rand.Rand) (v float64, ok bool) {
+	if r == nil {
+		return 0, false
+	}
+	defer func() {
+		if recover() != nil {
+			v, ok = 0, false
+		}
+	}()
+	return r.Float64(), true
 }
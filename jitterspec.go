@@ -0,0 +1,111 @@
+package backoff
+
+import (
+	"fmt"
+	"maps"
+)
+
+// JitterSpec is a serializable form of a jitter strategy: its name, as
+// returned by JitterByName, and its parameters, if any. Unlike the name
+// alone, it round-trips parameterized strategies such as BetaJitter.
+//
+// In JSON, it is encoded as an object:
+//
+//	{"type": "beta", "params": {"alpha": 2, "beta": 5}}
+type JitterSpec struct {
+	Type   string             `json:"type"`
+	Params map[string]float64 `json:"params,omitempty"`
+}
+
+// SpecOf returns the spec of a built-in jitter strategy. A nil Jitter is
+// described as "none". For AdaptiveJitter, only the configuration is
+// described, not the current fraction.
+//
+// Returns an error for jitter strategies that are not built in, since
+// their parameters are unknown.
+//
+// Example:
+//
+//	spec, err := SpecOf(BetaJitter{Alpha: 2, Beta: 5})
+//	if err != nil {
+//		return err
+//	}
+//	data, err := json.Marshal(spec)
+func SpecOf(j Jitter) (JitterSpec, error) {
+	switch j := j.(type) {
+	case nil, *NoneJitter, FullJitter, FullJitterFromZero, EqualJitter:
+		return JitterSpec{Type: jitterName(j)}, nil
+	case BetaJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"alpha": j.Alpha,
+			"beta":  j.Beta,
+		}}, nil
+	case ProportionalJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"fraction": j.Fraction,
+		}}, nil
+	case ElapsedProportionalJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"fraction": j.Fraction,
+		}}, nil
+	case SlottedJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"index": float64(j.Index),
+			"total": float64(j.Total),
+		}}, nil
+	case fixedJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"fraction": j.fraction,
+		}}, nil
+	case *AdaptiveJitter:
+		return JitterSpec{Type: j.String(), Params: map[string]float64{
+			"initial": j.initial,
+			"step":    j.step,
+			"limit":   j.limit,
+		}}, nil
+	default:
+		return JitterSpec{}, fmt.Errorf("backoff: cannot describe jitter %T", j)
+	}
+}
+
+// Jitter returns the jitter strategy described by the spec. Missing
+// parameters take their zero value, i.e. the strategy's default.
+//
+// Returns an error for unknown types and for parameters the type does
+// not have.
+func (s JitterSpec) Jitter() (Jitter, error) {
+	j, err := JitterByName(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	params := maps.Clone(s.Params)
+	take := func(name string) float64 {
+		v := params[name]
+		delete(params, name)
+		return v
+	}
+
+	switch j.(type) {
+	case BetaJitter:
+		j = BetaJitter{Alpha: take("alpha"), Beta: take("beta")}
+	case ProportionalJitter:
+		j = ProportionalJitter{Fraction: take("fraction")}
+	case ElapsedProportionalJitter:
+		j = ElapsedProportionalJitter{Fraction: take("fraction")}
+	case SlottedJitter:
+		j = SlottedJitter{Index: int(take("index")), Total: int(take("total"))}
+	case fixedJitter:
+		f := take("fraction")
+		if !(f > 0) {
+			f = 0 // as in WithDeterministicJitter
+		}
+		j = fixedJitter{fraction: f}
+	case *AdaptiveJitter:
+		j = NewAdaptiveJitter(take("initial"), take("step"), take("limit"))
+	}
+	for name := range params {
+		return nil, fmt.Errorf("backoff: unknown parameter %q for jitter %q", name, s.Type)
+	}
+	return j, nil
+}
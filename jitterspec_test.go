@@ -0,0 +1,80 @@
+package backoff
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestJitterSpec(t *testing.T) {
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		jitters := []Jitter{
+			&NoneJitter{},
+			FullJitter{},
+			FullJitterFromZero{},
+			EqualJitter{},
+			ProportionalJitter{Fraction: 0.2},
+			BetaJitter{Alpha: 2, Beta: 5},
+			ElapsedProportionalJitter{Fraction: 0.3},
+			SlottedJitter{Index: 3, Total: 8},
+			NewAdaptiveJitter(0.1, 0.2, 0.6),
+		}
+
+		for _, j := range jitters {
+			t.Run(jitterName(j), func(t *testing.T) {
+				spec, err := SpecOf(j)
+				if err != nil {
+					t.Fatalf("SpecOf: %v", err)
+				}
+				data, err := json.Marshal(spec)
+				if err != nil {
+					t.Fatalf("Marshal: %v", err)
+				}
+				var decoded JitterSpec
+				if err := json.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("Unmarshal: %v", err)
+				}
+				got, err := decoded.Jitter()
+				if err != nil {
+					t.Fatalf("Jitter: %v", err)
+				}
+
+				// Identical behavior on identical random streams
+				r1 := rand.New(rand.NewPCG(42, 1024))
+				r2 := rand.New(rand.NewPCG(42, 1024))
+				for i := 0; i < 100; i++ {
+					if a, b := j.Apply(time.Second, r1), got.Apply(time.Second, r2); a != b {
+						t.Fatalf("Sample %d: %v != %v after round trip of %s", i, a, b, data)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("encoding", func(t *testing.T) {
+		spec, _ := SpecOf(BetaJitter{Alpha: 2, Beta: 5})
+		data, _ := json.Marshal(spec)
+		if want := `{"type":"beta","params":{"alpha":2,"beta":5}}`; string(data) != want {
+			t.Errorf("Expected %s, got %s", want, data)
+		}
+
+		spec, _ = SpecOf(nil)
+		data, _ = json.Marshal(spec)
+		if want := `{"type":"none"}`; string(data) != want {
+			t.Errorf("Expected %s, got %s", want, data)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := SpecOf(inflateJitter{}); err == nil {
+			t.Error("Expected an error for a custom jitter")
+		}
+		if _, err := (JitterSpec{Type: "gaussian"}).Jitter(); err == nil {
+			t.Error("Expected an error for an unknown type")
+		}
+		if _, err := (JitterSpec{Type: "equal", Params: map[string]float64{"fraction": 0.3}}).Jitter(); err == nil {
+			t.Error("Expected an error for an unknown parameter")
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package backoff
+
+import (
+	"math"
+	"time"
+)
+
+// latencyCapFactor is the multiple of the p99 latency used as the maximum
+// interval by FromLatency.
+const latencyCapFactor = 2
+
+// FromLatency creates an exponential backoff tuned to the latency
+// distribution of a dependency, for users who know their latencies but
+// not how to pick a base and cap.
+//
+// The parameters are derived as:
+//   - base = p50: a typical request has had time to complete before the
+//     first retry
+//   - factor = 2
+//   - maxInterval = 2 * p99: no retry waits much longer than a slow request
+//
+// If p99 is less than p50, p50 is used for both. The derived maximum
+// interval is applied before opts, so any option, such as WithMaxInterval,
+// overrides it.
+//
+// Example:
+//
+//	// p50 = 80ms, p99 = 1.2s: 80ms, 160ms, 320ms, ... capped at 2.4s
+//	b := FromLatency(80*time.Millisecond, 1200*time.Millisecond,
+//		WithMaxRetries(8), WithJitter())
+func FromLatency(p50, p99 time.Duration, opts ...Option) *Exponential {
+	p99 = max(p99, p50)
+
+	limit := time.Duration(math.MaxInt64)
+	if p99 < limit/latencyCapFactor {
+		limit = p99 * latencyCapFactor
+	}
+
+	derived := make([]Option, 0, len(opts)+1)
+	derived = append(derived, WithMaxInterval(limit))
+	derived = append(derived, opts...)
+
+	return NewExponential(p50, 2.0, derived...)
+}
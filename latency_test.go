@@ -0,0 +1,42 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromLatency(t *testing.T) {
+	t.Run("derives base and cap", func(t *testing.T) {
+		b := FromLatency(100*time.Millisecond, 300*time.Millisecond)
+
+		expected := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			600 * time.Millisecond, // capped at 2 * p99
+			600 * time.Millisecond,
+		}
+		for i, want := range expected {
+			if d, _ := b.Next(); d != want {
+				t.Errorf("Attempt %d: expected %v, got %v", i, want, d)
+			}
+		}
+	})
+
+	t.Run("options override derived values", func(t *testing.T) {
+		b := FromLatency(100*time.Millisecond, 300*time.Millisecond,
+			WithMaxInterval(time.Second))
+
+		if d := b.DelayAt(4); d != time.Second {
+			t.Errorf("Expected overridden cap %v, got %v", time.Second, d)
+		}
+	})
+
+	t.Run("p99 below p50", func(t *testing.T) {
+		b := FromLatency(100*time.Millisecond, 10*time.Millisecond)
+
+		if d := b.DelayAt(4); d != 200*time.Millisecond {
+			t.Errorf("Expected cap of 2 * p50 = %v, got %v", 200*time.Millisecond, d)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package backoff
+
+import (
+	"sync"
+	"time"
+)
+
+// SharedLimiter enforces a minimum spacing between the attempts of all
+// strategies configured with WithSharedLimiter, e.g. independent retry
+// loops hitting the same backend. It works like a leaky bucket: every
+// successful call to Next() reserves the earliest slot that is both after
+// its own delay and at least the spacing after the previously reserved
+// slot, and the delay is extended to reach that slot.
+//
+// Together, the strategies therefore never schedule attempts faster than
+// one per spacing, which per-instance jitter alone cannot guarantee.
+//
+// SharedLimiter is safe for concurrent use. The strategies themselves are
+// not, so each goroutine should still use its own instance.
+type SharedLimiter struct {
+	spacing time.Duration
+	clock   Clock
+
+	mu   sync.Mutex
+	next time.Time // earliest time of the next slot, zero = now
+}
+
+// NewSharedLimiter creates a new shared limiter allowing one attempt per
+// spacing across all strategies using it. Time is read from clock, or
+// from the system clock if clock is nil.
+//
+// Example:
+//
+//	// At most 10 attempts per second against the backend, in total
+//	limiter := NewSharedLimiter(100*time.Millisecond, nil)
+//	for i := 0; i < workers; i++ {
+//		go func() {
+//			b := NewExponential(100*time.Millisecond, 2.0,
+//				WithSharedLimiter(limiter))
+//			// use b in this goroutine...
+//		}()
+//	}
+func NewSharedLimiter(spacing time.Duration, clock Clock) *SharedLimiter {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &SharedLimiter{spacing: max(spacing, 0), clock: clock}
+}
+
+// reserve reserves the earliest slot at least d from now and returns the
+// delay until that slot, which is never shorter than d.
+func (l *SharedLimiter) reserve(d time.Duration) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	at := now.Add(d)
+	if at.Before(l.next) {
+		at = l.next
+	}
+	l.next = at.Add(l.spacing)
+	return at.Sub(now)
+}
@@ -0,0 +1,88 @@
+package backoff
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharedLimiter(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("extends delays to the next slot", func(t *testing.T) {
+		clock := &fakeClock{now: epoch}
+		limiter := NewSharedLimiter(100*time.Millisecond, clock)
+		a := NewConstant(10*time.Millisecond, WithSharedLimiter(limiter))
+		b := NewConstant(10*time.Millisecond, WithSharedLimiter(limiter))
+
+		want := []time.Duration{10 * time.Millisecond, 110 * time.Millisecond, 210 * time.Millisecond}
+		for i, s := range []Sequence{a, b, a} {
+			if d, _ := s.Next(); d != want[i] {
+				t.Errorf("Call %d: expected %v, got %v", i+1, want[i], d)
+			}
+		}
+
+		// Delays longer than the backlog are not extended
+		clock.Advance(time.Second)
+		if d, _ := b.Next(); d != 10*time.Millisecond {
+			t.Errorf("Expected an idle limiter to keep the delay, got %v", d)
+		}
+	})
+
+	t.Run("rounds extended delays up", func(t *testing.T) {
+		limiter := NewSharedLimiter(1500*time.Microsecond, &fakeClock{now: epoch})
+		c := NewConstant(0, WithSharedLimiter(limiter), WithMillisecondResolution())
+
+		want := []time.Duration{0, 2 * time.Millisecond, 3 * time.Millisecond}
+		for i, w := range want {
+			if d, _ := c.Next(); d != w {
+				t.Errorf("Call %d: expected %v, got %v", i+1, w, d)
+			}
+		}
+	})
+
+	t.Run("diagnostics do not reserve slots", func(t *testing.T) {
+		clock := &fakeClock{now: epoch}
+		limiter := NewSharedLimiter(time.Second, clock)
+		e := NewExponential(10*time.Millisecond, 2.0, WithSharedLimiter(limiter))
+
+		e.DistinctDelays(5)
+		e.CumulativeDelay(5)
+		if d, _ := e.Next(); d != 10*time.Millisecond {
+			t.Errorf("Expected diagnostics to leave the limiter untouched, got %v", d)
+		}
+	})
+
+	t.Run("aggregate rate across goroutines", func(t *testing.T) {
+		const workers, calls = 8, 50
+		const spacing = 10 * time.Millisecond
+		limiter := NewSharedLimiter(spacing, &fakeClock{now: epoch})
+
+		var mu sync.Mutex
+		var delays []time.Duration
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b := NewConstant(0, WithSharedLimiter(limiter))
+				for j := 0; j < calls; j++ {
+					d, _ := b.Next()
+					mu.Lock()
+					delays = append(delays, d)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		// With time standing still, the attempts occupy consecutive slots
+		slices.Sort(delays)
+		for i, d := range delays {
+			if want := time.Duration(i) * spacing; d != want {
+				t.Fatalf("Slot %d: expected %v, got %v", i, want, d)
+			}
+		}
+	})
+}
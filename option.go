@@ -1,7 +1,10 @@
 package backoff
 
 import (
+	"context"
+	"math"
 	"math/rand/v2"
+	"slices"
 	"time"
 )
 
@@ -14,6 +17,13 @@ type Option func(*options)
 // Delays will be capped at this duration regardless of the backoff algorithm.
 // A value of 0 means no maximum limit.
 //
+// The cap is applied after jitter in every strategy that applies jitter, so
+// no Jitter implementation, even one that inflates delays, can push a delay
+// above it. Only WithScale, which is applied last, may exceed it. Constant
+// ignores bounds and jitter altogether.
+//
+// WithDynamicMaxInterval takes precedence over it when set.
+//
 // Example:
 //
 //	backoff := NewExponential(100*time.Millisecond, 2.0,
@@ -24,6 +34,61 @@ func WithMaxInterval(d time.Duration) Option {
 	}
 }
 
+// WithDynamicMaxInterval makes the maximum delay depend on the attempt,
+// so the cap can loosen as an outage persists. fn is called on every
+// Next() with the 0-based attempt number and returns the cap for that
+// step; a value of 0 means no maximum for that step.
+//
+// When set, fn takes precedence over WithMaxInterval, including the
+// default maxInterval of NewDecorrelated and WithSaneDefaults. The cap is
+// evaluated at the same point as a static one: after jitter and before
+// WithScale. As with WithMaxInterval, growth is not distorted, so the
+// delays pick up where they would have been once the cap is raised.
+//
+// Example:
+//
+//	// Cap at 1s for the first 5 attempts, then allow up to 30s
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithDynamicMaxInterval(func(attempt int) time.Duration {
+//			if attempt < 5 {
+//				return time.Second
+//			}
+//			return 30 * time.Second
+//		}))
+func WithDynamicMaxInterval(fn func(attempt int) time.Duration) Option {
+	return func(o *options) {
+		o.dynamicMax = fn
+	}
+}
+
+// WithFirstDelayRange clamps the first delay after construction or Reset()
+// to [min, max], independently of base and of the min/max bounds of later
+// delays. The growth of the following delays is computed as if the first
+// delay had not been clamped, so the curve is unchanged from the second
+// delay on.
+//
+// The first delay is only randomized within the window if the strategy
+// randomizes it anyway, e.g. with jitter or for Decorrelated; a delay
+// outside the window is clamped to its nearest edge. To stagger the first
+// attempt tightly, set base inside the window and add jitter. If min is
+// greater than max, the two are swapped. Constant ignores this option,
+// like all bounds.
+//
+// Example:
+//
+//	// First retry after 50-100ms, then 400ms, 800ms, ... as usual
+//	backoff := NewExponential(200*time.Millisecond, 2.0,
+//		WithJitter(),
+//		WithFirstDelayRange(50*time.Millisecond, 100*time.Millisecond))
+func WithFirstDelayRange(min, max time.Duration) Option {
+	return func(o *options) {
+		if min > max {
+			min, max = max, min
+		}
+		o.firstMin, o.firstMax, o.hasFirstRange = min, max, true
+	}
+}
+
 // WithMinInterval sets the minimum delay interval for backoff strategies.
 // Delays will never be shorter than this duration.
 // A value of 0 means no minimum limit.
@@ -40,7 +105,8 @@ func WithMinInterval(d time.Duration) Option {
 
 // WithMaxRetries sets the maximum number of retry attempts.
 // After this many retries, Next() will return (0, false).
-// A value of -1 means unlimited retries.
+// A value of -1 means unlimited retries. A value of 0 disables retries:
+// every strategy returns (0, false) from the very first call to Next().
 //
 // Example:
 //
@@ -66,22 +132,453 @@ func WithMaxElapsed(d time.Duration) Option {
 	}
 }
 
+// WithContextDeadline sets the max elapsed time to the time left until the
+// deadline of ctx, read once at construction. This replaces computing the
+// duration by hand for WithMaxElapsed, and forgetting to do so. Like
+// WithMaxElapsed, it is measured against the sum of the returned delays,
+// unless WithStartTime is set; the time spent in the operations between
+// the delays is not counted.
+//
+// The time left is measured with the configured Clock once all options
+// are applied. If ctx has no deadline, the option has no effect and no
+// time limit is set. If the deadline has already passed, no retries are
+// allowed, whatever the other options say. Together with WithMaxElapsed,
+// the smaller limit applies. Reset() does not read the deadline again.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+//	defer cancel()
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithContextDeadline(ctx))
+func WithContextDeadline(ctx context.Context) Option {
+	return func(o *options) {
+		if deadline, ok := ctx.Deadline(); ok {
+			o.deadline = deadline
+		}
+	}
+}
+
+// WithElapsedJitter randomizes the max elapsed limit of each instance
+// within [max*(1-fraction), max*(1+fraction)], so that a fleet of clients
+// sharing the same configuration does not give up at the same instant.
+// The fraction is clamped to [0, 1], and NaN is treated as 0.
+//
+// The effective limit is drawn once at construction. With a seeded
+// WithRandSource it is reproducible, so each client needs its own seed.
+// Without WithRandSource it is drawn from a randomly seeded generator
+// rather than the fixed-seed default. The option has no effect without
+// WithMaxElapsed.
+//
+// Example:
+//
+//	// Give up somewhere between 48s and 72s
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithMaxElapsed(time.Minute),
+//		WithElapsedJitter(0.2))
+func WithElapsedJitter(fraction float64) Option {
+	return func(o *options) {
+		if math.IsNaN(fraction) {
+			fraction = 0
+		}
+		o.elapsedJitter = min(max(fraction, 0), 1)
+	}
+}
+
+// WithElapsedOffset pre-charges the elapsed time budget with d, as if d
+// had already been spent before the first call to Next(). This is useful
+// when resuming work or when some time was spent before backing off,
+// e.g. "I already spent 5s before starting backoff".
+//
+// Reset() restores the elapsed time to d rather than to zero. The offset
+// only applies to the sum of returned delays and has no effect together
+// with WithStartTime.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithMaxElapsed(30*time.Second),
+//		WithElapsedOffset(5*time.Second)) // 25s of budget left
+func WithElapsedOffset(d time.Duration) Option {
+	return func(o *options) {
+		o.elapsedOffset = d
+	}
+}
+
+// WithFillBudget makes Exponential use up the remaining elapsed budget
+// instead of giving up early. When the next delay would exceed maxElapsed,
+// it is truncated to exactly the remaining budget and one last attempt is
+// granted; the following call to Next() returns (0, false).
+// Without this option, the remaining budget is discarded.
+//
+// Example:
+//
+//	backoff := NewExponential(time.Second, 2.0,
+//		WithMaxElapsed(10*time.Second),
+//		WithFillBudget()) // 1s, 2s, 4s, then 3s
+func WithFillBudget() Option {
+	return func(o *options) {
+		o.fillBudget = true
+	}
+}
+
+// WithEvenDistribution makes Exponential spread its delays over the whole
+// elapsed budget. When both WithMaxElapsed and WithMaxRetries are set, the
+// base is scaled so that the un-jittered delays of exactly maxRetries
+// attempts add up to just under the remaining budget, keeping the growth
+// factor. Without it, an exponential sequence often overshoots the budget
+// after a few of the allowed retries.
+//
+// The option has no effect if either limit is missing. Bounds, jitter and
+// WithAdditiveBase are applied on top of the scaled delays and may change
+// the total.
+//
+// Example:
+//
+//	// 10 attempts over about a minute: ~59ms, ~117ms, ~235ms, ...
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithMaxRetries(10),
+//		WithMaxElapsed(time.Minute),
+//		WithEvenDistribution())
+func WithEvenDistribution() Option {
+	return func(o *options) {
+		o.evenDistribution = true
+	}
+}
+
+// WithSharedBudget makes the strategy draw its retries from a budget shared
+// with other strategies, in addition to its own limits. Reset() does not
+// refill the shared budget.
+//
+// Example:
+//
+//	budget := NewSharedBudget(100) // at most 100 retries across the pool
+//	backoff := NewConstant(time.Second, WithSharedBudget(budget))
+func WithSharedBudget(b *SharedBudget) Option {
+	return func(o *options) {
+		o.shared = b
+	}
+}
+
+// WithMillisecondResolution truncates every returned delay to whole
+// milliseconds, for downstream systems that log or schedule in
+// milliseconds and are confused by the nanosecond noise of jitter. A
+// positive delay is never truncated to zero: delays below 1ms become 1ms.
+//
+// The truncation is the last step of Next(), after bounds, scaling and the
+// elapsed time check, and the elapsed time is charged with the truncated
+// delay. Only a delay extended by WithSharedLimiter is rounded up instead,
+// so that it does not fire before its slot.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithJitter(),
+//		WithMillisecondResolution())
+func WithMillisecondResolution() Option {
+	return func(o *options) {
+		o.resolution = time.Millisecond
+	}
+}
+
+// WithTickAlignment rounds every returned delay up to the next point of
+// a grid of period spacing, shifted by offset, i.e. to the smallest
+// k*period + offset with k >= 0 that is not shorter than the delay. For
+// batch-processing backends that only act on a fixed schedule, this makes
+// retries land just after a processing tick instead of at random. The grid
+// is relative to the call to Next(); choose the offset so that it matches
+// the phase of the remote ticks, e.g. with the help of AlignTo.
+//
+// The offset is reduced to the range [0, period). Rounding up happens
+// after bounds, scaling and the elapsed time check, so an aligned delay
+// may exceed WithMaxInterval or the WithMaxElapsed budget by up to one
+// period; the elapsed time is charged with the aligned delay. A period <= 0
+// disables alignment.
+//
+// Example:
+//
+//	// The backend processes its queue every 5s; retry 200ms after a tick
+//	backoff := NewExponential(time.Second, 2.0,
+//		WithTickAlignment(5*time.Second, 200*time.Millisecond))
+func WithTickAlignment(period, offset time.Duration) Option {
+	return func(o *options) {
+		if period <= 0 {
+			o.tickPeriod, o.tickOffset = 0, 0
+			return
+		}
+		offset %= period
+		if offset < 0 {
+			offset += period
+		}
+		o.tickPeriod, o.tickOffset = period, offset
+	}
+}
+
+// WithSharedLimiter makes the strategy space its attempts out with those
+// of all other strategies using l: every successful Next() extends its
+// delay, if necessary, to the next free slot of the limiter.
+//
+// The extension is applied last, after bounds, scaling and the elapsed
+// time check, so a throttled delay may exceed maxInterval and the final
+// one may overrun maxElapsed; it does count towards the elapsed time of
+// later calls. Diagnostics such as DistinctDelays do not reserve slots.
+//
+// Example:
+//
+//	limiter := NewSharedLimiter(100*time.Millisecond, nil) // 10/s in total
+//	backoff := NewExponential(50*time.Millisecond, 2.0,
+//		WithSharedLimiter(limiter))
+func WithSharedLimiter(l *SharedLimiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
+// WithFlatCap makes Decorrelated settle into a steady state once its growth
+// reaches maxInterval: every following delay is picked at random between
+// minInterval and maxInterval, instead of continuing to derive the range
+// from the previous delay. This gives predictable behavior during prolonged
+// outages. Reset() leaves the steady state.
+//
+// Example:
+//
+//	backoff := NewDecorrelated(100*time.Millisecond, 3.0,
+//		WithMinInterval(time.Second),
+//		WithMaxInterval(10*time.Second),
+//		WithFlatCap()) // eventually random(1s, 10s) on every retry
+func WithFlatCap() Option {
+	return func(o *options) {
+		o.flatCap = true
+	}
+}
+
+// WithAdditiveBase adds d to every delay returned by Exponential, shifting
+// the whole curve up: delay = d + base*factor^attempt. Unlike
+// WithMinInterval, which only raises delays below the minimum, this keeps
+// the growth visible from the very first retry while ensuring early delays
+// are not tiny.
+//
+// The offset is added after jitter and before min/max bounds, so it is a
+// constant floor that jitter does not randomize, and does not feed back
+// into the growth of subsequent delays.
+//
+// Example:
+//
+//	// 1.01s, 1.02s, 1.04s, 1.08s, ...
+//	backoff := NewExponential(10*time.Millisecond, 2.0,
+//		WithAdditiveBase(time.Second))
+func WithAdditiveBase(d time.Duration) Option {
+	return func(o *options) {
+		o.additiveBase = d
+	}
+}
+
+// WithDeadlineFactor makes Deadline weight its delays exponentially: each
+// delay is factor times the previous one, while all delays still add up to
+// the total duration. A factor <= 0, equal to 1, NaN or infinite spaces the
+// delays evenly. The option is only read by NewDeadline; the other
+// strategies ignore it.
+//
+// Example:
+//
+//	// 1s, 2s, 4s, 8s within 15s
+//	backoff := NewDeadline(15*time.Second, 4, WithDeadlineFactor(2.0))
+func WithDeadlineFactor(factor float64) Option {
+	return func(o *options) {
+		o.deadlineFactor = factor
+	}
+}
+
+// WithRandomStart advances the sequence by a random number of steps in
+// [0, maxSkip] at construction, so that instances created at the same time,
+// e.g. by workers booting simultaneously, start at different points of the
+// schedule. With a seeded WithRandSource the skip is reproducible, so each
+// worker needs its own seed. Without WithRandSource the skip is drawn from
+// a randomly seeded generator rather than the fixed-seed default.
+//
+// The skip advances only the growth of the schedule: no jitter is applied
+// and no callback such as WithScale is called, and the first delay
+// returned is still the one WithFirstDelayRange and WithFreeFirstAttempt
+// apply to. The skipped steps count toward WithMaxRetries, reducing the
+// effective number of retries left, but not toward WithMaxElapsed.
+// Reset() rewinds to the very beginning of the schedule, not to the random
+// start.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithRandSource(rand.NewPCG(workerID, 0)),
+//		WithRandomStart(3)) // first delay is 100ms, 200ms, 400ms or 800ms
+func WithRandomStart(maxSkip int) Option {
+	return func(o *options) {
+		o.randomStart = maxSkip
+	}
+}
+
+// WithResetHook registers fn to be called at the end of every explicit
+// call to Reset(), after the state has been cleared. It is not called at
+// construction or by SoftReset. This is useful to count recoveries, e.g.
+// in a reconnect loop that resets the backoff after a success.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithResetHook(func() { recoveries.Inc() }))
+func WithResetHook(fn func()) Option {
+	return func(o *options) {
+		o.resetHook = fn
+	}
+}
+
+// WithFreeFirstAttempt exempts the first delay after construction or
+// Reset() from the elapsed budget: it is neither checked against
+// WithMaxElapsed nor added to the elapsed time. Since the delay is charged
+// before the operation runs, the first wait otherwise counts against the
+// budget although nothing has failed for long yet; with this option, it is
+// one unbudgeted retry on top of those that fit in maxElapsed.
+//
+// The option only affects the elapsed time summed from the returned
+// delays. With WithStartTime, elapsed time is read from the clock, and
+// only the check of the first delay is skipped. Retry limits still count
+// the first delay.
+//
+// Example:
+//
+//	// 1s free, then as many 1s delays as fit in 3s: 4 retries in total
+//	backoff := NewConstant(time.Second,
+//		WithMaxElapsed(3*time.Second),
+//		WithFreeFirstAttempt())
+func WithFreeFirstAttempt() Option {
+	return func(o *options) {
+		o.freeFirst = true
+	}
+}
+
+// WithResetCooldown debounces Reset(): a call within d of the last
+// effective reset is ignored, so that a flapping dependency, alternating
+// rapidly between success and failure, does not collapse the backoff on
+// every brief recovery. The cooldown is measured on the Clock set with
+// WithClock from the last reset that took effect; the first reset always
+// takes effect. Ignored resets do not call the WithResetHook hook.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithResetCooldown(30*time.Second))
+func WithResetCooldown(d time.Duration) Option {
+	return func(o *options) {
+		o.resetCooldown = d
+	}
+}
+
+// WithChannelFallback makes ChannelSequence non-blocking: if no delay is
+// ready on its channel, Next() returns d instead of waiting. It has no
+// effect on other strategies.
+//
+// Example:
+//
+//	b := NewChannelSequence(controller.Delays(),
+//		WithChannelFallback(time.Second))
+func WithChannelFallback(d time.Duration) Option {
+	return func(o *options) {
+		o.fallback, o.hasFallback = d, true
+	}
+}
+
+// WithSaneDefaults guards against a misconfigured strategy sleeping for
+// absurd durations: if retries are unlimited and no maxInterval is set,
+// maxInterval defaults to 30 seconds, like NewDecorrelated already does.
+// Without it, an Exponential retrying forever eventually returns a delay
+// of math.MaxInt64, about 292 years.
+//
+// An explicit WithMaxInterval always takes precedence, regardless of the
+// order the options are given in.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithSaneDefaults()) // never waits more than 30s
+func WithSaneDefaults() Option {
+	return func(o *options) {
+		o.saneDefaults = true
+	}
+}
+
 // WithRandSource sets a custom random source for jitter calculations.
 // This allows for deterministic testing or custom randomization behavior.
 // If not specified, a default PCG source with fixed seed is used.
 //
+// Strategies draw all random values from this source and read the time
+// only from the Clock set with WithClock, never from time.Now() or a
+// global generator. With both injected, a strategy is fully deterministic,
+// which makes it suitable for fuzz and property-based tests.
+//
+// Each call to Next() draws a fixed number of random values, so that two
+// instances seeded alike stay in lockstep:
+//   - the raw delay: 1 for Decorrelated, WeightedRandom and Pacer, none
+//     for the other strategies; Constant never applies jitter
+//   - the jitter: 1 for FullJitter, FullJitterFromZero, EqualJitter,
+//     ProportionalJitter, and for ElapsedProportionalJitter once elapsed
+//     time is spent; none for NoneJitter, SlottedJitter and
+//     WithDeterministicJitter
+//
+// BetaJitter and custom jitters draw a varying number. A draw over a range
+// that holds a single value, e.g. a Pacer without spread, is skipped, and
+// in rare cases, with a probability of range/2^64, rejection sampling
+// takes a second value from the source. WithElapsedJitter and
+// WithRandomStart draw from s at construction. backofftest.CountingSource
+// checks this contract.
+//
 // Example:
 //
 //	source := rand.NewPCG(42, 1024)
 //	backoff := NewExponential(100*time.Millisecond, 2.0,
 //		WithRandSource(source),
 //		WithJitter())
+//
+// A nil source is ignored, keeping the default or previously set source,
+// rather than failing later inside Next().
 func WithRandSource(s rand.Source) Option {
 	return func(o *options) {
-		o.rand = rand.New(s)
+		if s == nil {
+			return
+		}
+		o.rand, o.src, o.randSet = rand.New(s), s, true
+	}
+}
+
+// WithFastRand draws all random values from the top-level functions of
+// math/rand/v2, which are safe for concurrent use and lock-free, instead
+// of a per-strategy generator. This removes random number generation as a
+// contention point when many strategies on hot concurrent paths would
+// otherwise share a synchronized source.
+//
+// The top-level generator is randomly seeded and cannot be seeded, so the
+// delays are no longer reproducible: WithRandSource and a Clock no longer
+// make a strategy deterministic. Use it only when determinism is not
+// required. A later WithRandSource overrides it, and vice versa.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithJitter(),
+//		WithFastRand())
+func WithFastRand() Option {
+	return func(o *options) {
+		o.rand, o.src, o.randSet = rand.New(globalSource{}), globalSource{}, true
 	}
 }
 
+// globalSource is a rand.Source backed by the top-level functions of
+// math/rand/v2. A *rand.Rand using it holds no state of its own and can be
+// shared between goroutines. See WithFastRand.
+type globalSource struct{}
+
+// Uint64 returns rand.Uint64().
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
 // WithJitter enables equal jitter for the backoff strategy.
 // Equal jitter adds randomness to delay intervals by using half the
 // calculated delay plus a random amount up to the other half.
@@ -111,29 +608,184 @@ func WithJitterStrategy(j Jitter) Option {
 	}
 }
 
+// WithDeterministicJitter is a testing aid: it makes jitter return exactly
+// d * fraction instead of a random value, so tests of code built on this
+// package can assert exact delays without reasoning about the random source.
+// It takes precedence over WithJitter and WithJitterStrategy regardless of
+// the order the options are given in. Do not use it in production, as it
+// removes the spreading of retries that jitter exists for.
+//
+// A negative or NaN fraction is treated as 0. Decorrelated's own
+// randomization is not jitter and stays random.
+//
+// Example:
+//
+//	// Always 75% of the calculated delay
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithJitter(),
+//		WithDeterministicJitter(0.75))
+func WithDeterministicJitter(fraction float64) Option {
+	return func(o *options) {
+		if !(fraction > 0) {
+			fraction = 0
+		}
+		o.fixedJitter = &fixedJitter{fraction: fraction}
+	}
+}
+
+// WithClock sets the time source used for wall-clock elapsed tracking.
+// It only has an effect together with WithStartTime or
+// WithContextDeadline. If not specified, the system clock is used.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithClock(myClock),
+//		WithStartTime(job.CreatedAt),
+//		WithMaxElapsed(time.Minute))
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithStartTime measures the max elapsed limit from the given start time
+// instead of from the first call to Next(). Once set, the elapsed time is
+// clock.Now().Sub(t) rather than the sum of the returned delays, so a job
+// that was created or resumed long before its first retry correctly accounts
+// for the time it has already spent.
+//
+// With the default system clock and a t obtained from time.Now(), elapsed
+// time is measured on the monotonic clock and is not affected by wall-clock
+// adjustments such as NTP corrections. In any case, the measured elapsed
+// time never decreases, even if the Clock reports time going backwards.
+//
+// Example:
+//
+//	backoff := NewConstant(time.Second,
+//		WithStartTime(job.CreatedAt),
+//		WithMaxElapsed(5*time.Minute))
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.start = t
+	}
+}
+
+const (
+	minScale = 0.01 // lower clamp for WithScale
+	maxScale = 100  // upper clamp for WithScale
+)
+
+// WithScale multiplies every returned delay by the value of fn.
+// The scale is read fresh on each call to Next(), so it can be changed at
+// runtime, e.g. to slow down all retries fleet-wide during an outage.
+//
+// The scale is clamped to [0.01, 100]; NaN leaves the delay unchanged.
+// Scaling is applied last, after jitter and min/max bounds, and does not
+// feed back into the growth of subsequent delays.
+//
+// Example:
+//
+//	var factor atomic.Value // holds a float64, updated by an admin endpoint
+//	factor.Store(1.0)
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithScale(func() float64 { return factor.Load().(float64) }))
+func WithScale(fn func() float64) Option {
+	return func(o *options) {
+		o.scale = fn
+	}
+}
+
+// WithHealthScore modulates every returned delay by a health gauge read
+// fresh on each call to Next(), from 0.0 (unhealthy) to 1.0 (healthy):
+// the delay is interpolated between the min interval, when healthy, and
+// the full computed delay, when unhealthy.
+//
+//	delay = minInterval + (computed - minInterval) * (1 - score)
+//
+// This lets a shared health signal shorten retries in real time while a
+// dependency is known to be up, and restore the full backoff when it is
+// not. The score is clamped to [0, 1]; NaN leaves the delay unchanged.
+// Like WithScale, with which it combines, it is applied after jitter and
+// min/max bounds and does not feed back into the growth of subsequent
+// delays. Without WithMinInterval, a fully healthy score means no delay.
+//
+// Example:
+//
+//	backoff := NewExponential(100*time.Millisecond, 2.0,
+//		WithMinInterval(10*time.Millisecond),
+//		WithHealthScore(pool.HealthyFraction))
+func WithHealthScore(fn func() float64) Option {
+	return func(o *options) {
+		o.health = fn
+	}
+}
+
 // applyOptions creates a new options struct with default values and
 // applies all provided option functions to configure the backoff behavior.
 //
 // Default values:
 //   - maxRetries: -1 (unlimited)
 //   - maxElapsed: 0 (no time limit)
-//   - rand: PCG source with seed (42, 1024)
+//   - rand: PCG source with seed (42, 1024); draws made at construction
+//     use a randomly seeded generator instead (see startRand)
 //   - maxInterval: 0 (no maximum)
 //   - minInterval: 0 (no minimum)
 //   - jitter: NoneJitter (no jitter)
+//   - clock: system clock
+//   - start: zero (elapsed is the sum of returned delays)
 func applyOptions(opts []Option) *options {
+	src := rand.NewPCG(42, 1024)
 	o := &options{
 		maxRetries:  -1,
 		maxElapsed:  0,
-		rand:        rand.New(rand.NewPCG(42, 1024)),
+		rand:        rand.New(src),
+		src:         src,
 		maxInterval: 0,
 		minInterval: 0,
 		jitter:      &NoneJitter{},
+		clock:       systemClock{},
 	}
 
 	for _, opt := range opts {
 		opt(o)
 	}
+	if o.fixedJitter != nil {
+		o.jitter = *o.fixedJitter
+	}
+	if o.saneDefaults && o.maxRetries < 0 && o.maxInterval <= 0 {
+		o.maxInterval = defaultMaxInterval
+	}
+	if !o.deadline.IsZero() {
+		left := o.deadline.Sub(o.clock.Now())
+		switch {
+		case left <= 0:
+			o.expired = true
+		case o.maxElapsed <= 0 || left < o.maxElapsed:
+			o.maxElapsed = left
+		}
+	}
+	if o.elapsedJitter > 0 && o.maxElapsed > 0 {
+		o.maxElapsed = jitterLimit(o.maxElapsed, o.elapsedJitter, o.startRand())
+	}
+	o.opts = slices.Clone(opts) // the caller may reuse the slice
 
 	return o
 }
+
+// jitterLimit returns a random duration in [d*(1-fraction), d*(1+fraction)],
+// capped at math.MaxInt64. If the random number generator fails, d is
+// returned unchanged.
+func jitterLimit(d time.Duration, fraction float64, r *rand.Rand) time.Duration {
+	low := time.Duration(float64(d) * (1 - fraction))
+	high := time.Duration(math.MaxInt64)
+	if v := float64(d) * (1 + fraction); v < float64(math.MaxInt64) {
+		high = time.Duration(v)
+	}
+
+	v, ok := randInt64N(r, int64(high-low)+1)
+	if !ok {
+		return d
+	}
+	return low + time.Duration(v)
+}
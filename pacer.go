@@ -0,0 +1,239 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"math"
+	"time"
+)
+
+// Pacer implements a strategy that returns a fixed interval randomized by
+// up to a given spread in either direction.
+//
+// Use Pacer for periodic tasks such as health checks, where the delay
+// should not grow, but instances must not fire in lockstep.
+type Pacer struct {
+	options  *options
+	interval time.Duration // center of the delays
+	spread   time.Duration // maximum deviation from interval
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewPacer creates a new pacer.
+//
+// Parameters:
+//   - interval: The average delay
+//   - spread: Maximum deviation from interval, in either direction
+//   - opts: Optional configuration functions
+//
+// A negative spread is treated as its absolute value.
+//
+// Example:
+//
+//	// Every 10s ± up to 2s, never less than 9s
+//	p := NewPacer(10*time.Second, 2*time.Second,
+//		WithMinInterval(9*time.Second))
+func NewPacer(interval, spread time.Duration, opts ...Option) *Pacer {
+	o := applyOptions(opts)
+
+	p := &Pacer{
+		options:  o,
+		interval: interval,
+		spread:   max(spread, -spread),
+		elapsed:  o.elapsedOffset,
+	}
+	if p.spread < 0 {
+		p.spread = math.MaxInt64 // -math.MinInt64 overflows
+	}
+	p.skip(o.startSkip())
+
+	return p
+}
+
+// Next returns interval plus a random offset in [-spread, spread],
+// clamped to non-negative values, subject to jitter and min/max bounds.
+//
+// Returns:
+//   - time.Duration: The calculated delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+func (p *Pacer) Next() (time.Duration, bool) {
+	if p.options.outOfRetries(p.retries) {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	d := addDuration(p.interval, p.offset())
+	d = p.options.applyJitter(d, p.elapsed)
+	d = applyBounds(d, p.options.minInterval, p.options.maxAt(p.retries))
+	d = p.options.firstRange(d, p.retries)
+	d = p.options.scaled(d)
+	if p.options.maxElapsed > 0 && !p.options.free(p.retries) && d >= p.options.maxElapsed-p.options.spent(p.elapsed) {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	if !p.options.acquire() {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	d = p.options.finish(d)
+	p.elapsed += p.options.charge(p.retries, d)
+	p.retries++
+	p.last, p.hasLast = d, true
+	return d, true
+}
+
+// offset returns a random duration in [-spread, spread]. The range is
+// drawn as a uint64, since 2*spread+1 overflows an int64 for spreads above
+// math.MaxInt64/2. It returns 0 if the random number generator fails.
+func (p *Pacer) offset() time.Duration {
+	v, ok := randUint64N(p.options.rand, 2*uint64(p.spread)+1)
+	if !ok {
+		return 0
+	}
+	if spread := uint64(p.spread); v < spread {
+		return -time.Duration(spread - v)
+	}
+	return time.Duration(v - uint64(p.spread))
+}
+
+// NextContext is like Next, but returns (0, false) without drawing a delay
+// if ctx is done.
+func (p *Pacer) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, p)
+}
+
+// skip advances the retry count by n steps, as WithRandomStart does at
+// construction.
+func (p *Pacer) skip(n int) {
+	p.retries += n
+}
+
+// JitterSpread returns the standard deviation of the next delay of p,
+// which reflects both the spread and jitter; see Common methods.
+func (p *Pacer) JitterSpread(n int) time.Duration {
+	return jitterSpread(p, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of p; see Common methods.
+func (p *Pacer) DistinctDelays(n int) int {
+	return distinctDelays(p, n)
+}
+
+// fork returns a copy of the pacer including its state.
+func (p *Pacer) fork(mod func(*options)) Sequence {
+	f := *p
+	f.options = forkOptions(p.options, mod)
+	return &f
+}
+
+// Reset resets the pacer to its initial state.
+// This clears the retry count and the delay reported by Current, and
+// restores the elapsed time to its initial offset.
+func (p *Pacer) Reset() {
+	if !p.options.allowReset() {
+		return
+	}
+	p.retries = 0
+	p.elapsed = p.options.elapsedOffset
+	p.last = 0
+	p.hasLast = false
+	p.options.reset()
+}
+
+// SoftReset halves the retry count, rounded down, and clears the delay
+// reported by Current. Unlike Reset, the elapsed time is kept. Since the
+// delays do not depend on the retry count, this only gives retries back.
+func (p *Pacer) SoftReset() {
+	p.retries /= 2
+	p.last = 0
+	p.hasLast = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (p *Pacer) Current() (time.Duration, bool) {
+	return p.last, p.hasLast
+}
+
+// Attempts returns the number of delays returned since construction or
+// Reset, less those rewound by SoftReset.
+func (p *Pacer) Attempts() int {
+	return p.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (p *Pacer) Elapsed() time.Duration {
+	return p.elapsed
+}
+
+// RemainingRetries returns the retries p has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (p *Pacer) RemainingRetries() int {
+	return remainingRetries(p.options, p.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (p *Pacer) budget() (limit, spent time.Duration) {
+	return p.options.maxElapsed, p.options.spent(p.elapsed)
+}
+
+// Remaining returns the elapsed budget p has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (p *Pacer) Remaining() time.Duration {
+	return remaining(p)
+}
+
+// Derive returns a pacer with the same interval, spread and options that
+// is charged with the elapsed time of p, so that a nested operation stops
+// at the same deadline.
+func (p *Pacer) Derive() *Pacer {
+	return derive(p, p)
+}
+
+// Clone returns an independent copy of p. With the default random source,
+// the copy draws the same delays p would; pass another WithRandSource to
+// WithOverrides instead for a pacer that fires apart from p.
+func (p *Pacer) Clone() *Pacer {
+	return clone(p)
+}
+
+// Iter returns an iterator over the next delays of p, see Iterate. Without
+// WithMaxRetries or WithMaxElapsed the loop must break on its own.
+func (p *Pacer) Iter() iter.Seq[time.Duration] {
+	return Iterate(p)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of p ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (p *Pacer) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, p, p.options, now)
+}
+
+// Describe returns the configuration of the pacer.
+func (p *Pacer) Describe() Description {
+	return p.options.describe(Description{
+		Kind:   KindPacer,
+		Base:   p.interval,
+		Spread: p.spread,
+	})
+}
+
+// WithOverrides returns a new pacer with the same interval, spread and
+// options, plus opts applied on top, starting from its initial state.
+func (p *Pacer) WithOverrides(opts ...Option) Sequence {
+	return NewPacer(p.interval, p.spread, p.options.with(opts)...)
+}
@@ -0,0 +1,115 @@
+package backoff
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestPacer(t *testing.T) {
+	t.Run("stays within the spread", func(t *testing.T) {
+		interval, spread := 10*time.Second, 2*time.Second
+		p := NewPacer(interval, spread, WithRandSource(rand.NewPCG(42, 1024)))
+
+		var below, above bool
+		for i := 0; i < 1000; i++ {
+			d, ok := p.Next()
+			if !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+			if d < interval-spread || d > interval+spread {
+				t.Fatalf("Call %d: %v outside [%v, %v]", i+1, d, interval-spread, interval+spread)
+			}
+			below = below || d < interval-spread/2
+			above = above || d > interval+spread/2
+		}
+		if !below || !above {
+			t.Error("Expected delays on both sides of the interval")
+		}
+	})
+
+	t.Run("clamped to bounds", func(t *testing.T) {
+		p := NewPacer(10*time.Second, 2*time.Second,
+			WithMinInterval(9*time.Second), WithMaxInterval(11*time.Second))
+		for i := 0; i < 1000; i++ {
+			d, _ := p.Next()
+			if d < 9*time.Second || d > 11*time.Second {
+				t.Fatalf("Call %d: %v outside [9s, 11s]", i+1, d)
+			}
+		}
+	})
+
+	t.Run("never negative", func(t *testing.T) {
+		p := NewPacer(time.Second, 5*time.Second)
+		for i := 0; i < 1000; i++ {
+			if d, _ := p.Next(); d < 0 {
+				t.Fatalf("Call %d: negative delay %v", i+1, d)
+			}
+		}
+	})
+
+	t.Run("huge interval and spread", func(t *testing.T) {
+		for _, spread := range []time.Duration{math.MaxInt64, math.MinInt64, math.MaxInt64 / 2} {
+			p := NewPacer(math.MaxInt64, spread)
+			zeros := 0
+			for i := 0; i < 1000; i++ {
+				d, ok := p.Next()
+				if !ok || d < 0 {
+					t.Fatalf("Spread %d, call %d: got (%v, %v)", spread, i+1, d, ok)
+				}
+				if d == 0 {
+					zeros++
+				}
+			}
+			if zeros > 10 {
+				t.Errorf("Spread %d: expected almost no zero delays, got %d", spread, zeros)
+			}
+		}
+	})
+
+	t.Run("zero spread", func(t *testing.T) {
+		p := NewPacer(time.Second, 0)
+		for i := 0; i < 5; i++ {
+			if d, _ := p.Next(); d != time.Second {
+				t.Errorf("Expected 1s without spread, got %v", d)
+			}
+		}
+	})
+
+	t.Run("honors limits", func(t *testing.T) {
+		p := NewPacer(100*time.Millisecond, 10*time.Millisecond,
+			WithMaxRetries(5), WithMaxElapsed(250*time.Millisecond))
+
+		attempts := 0
+		for {
+			if _, ok := p.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 2 {
+			t.Errorf("Expected 2 attempts within 250ms, got %d", attempts)
+		}
+
+		p.Reset()
+		if _, ok := p.Next(); !ok {
+			t.Error("Expected Next() to succeed after Reset()")
+		}
+	})
+
+	t.Run("stops at max elapsed", func(t *testing.T) {
+		p := NewPacer(100*time.Millisecond, 0, WithMaxElapsed(200*time.Millisecond))
+
+		attempts := 0
+		for {
+			if _, ok := p.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt when the second delay reaches max elapsed, got %d", attempts)
+		}
+	})
+}
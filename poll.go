@@ -0,0 +1,71 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrExhausted is returned when a helper gives up because the sequence
+// reports that no more retries are allowed. Poll returns it as is; the
+// retry helpers, which have an error from the operation, return an error
+// wrapping both, so check for it with errors.Is.
+var ErrExhausted = errors.New("backoff: retries exhausted")
+
+// Poll calls done until it reports that the polled resource is ready,
+// sleeping for the delay returned by s.Next() between calls.
+//
+// The done function distinguishes three outcomes:
+//   - (true, nil): the resource is ready, Poll returns nil
+//   - (false, nil): not ready yet, Poll waits and polls again
+//   - (_, err): the resource reached a terminal failure state, Poll
+//     returns err immediately without further polling
+//
+// With WithRecover, a panic in done is treated as a terminal failure and
+// returned as a *PanicError.
+//
+// Returns ErrExhausted if the sequence is exhausted before the resource is
+// ready, or the context error if ctx is cancelled.
+//
+// Example:
+//
+//	err := Poll(ctx, NewConstant(5*time.Second, WithMaxElapsed(10*time.Minute)),
+//		func(ctx context.Context) (bool, error) {
+//			vm, err := api.GetVM(ctx, id)
+//			if err != nil {
+//				return false, nil // transient, poll again
+//			}
+//			if vm.State == "FAILED" {
+//				return false, fmt.Errorf("provisioning failed: %s", vm.Reason)
+//			}
+//			return vm.State == "RUNNING", nil
+//		})
+func Poll(ctx context.Context, s Sequence, done func(context.Context) (bool, error), opts ...RetryOption) error {
+	o := applyRetryOptions(opts)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var ready bool
+		err := o.call(func() (err error) {
+			ready, err = done(ctx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		d, ok := s.Next()
+		if !ok {
+			return ErrExhausted
+		}
+
+		if _, err := o.wait(ctx, d); err != nil {
+			return err
+		}
+	}
+}
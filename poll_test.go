@@ -0,0 +1,67 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		calls := 0
+		err := Poll(context.Background(), NewConstant(time.Millisecond), func(ctx context.Context) (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil {
+			t.Fatalf("Expected nil once ready, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 polls, got %d", calls)
+		}
+	})
+
+	t.Run("not ready until exhausted", func(t *testing.T) {
+		calls := 0
+		err := Poll(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(2)), func(ctx context.Context) (bool, error) {
+			calls++
+			return false, nil
+		})
+		if !errors.Is(err, ErrExhausted) {
+			t.Errorf("Expected ErrExhausted, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 polls (1 + 2 retries), got %d", calls)
+		}
+	})
+
+	t.Run("terminal failure", func(t *testing.T) {
+		errFailed := errors.New("resource failed")
+		calls := 0
+		err := Poll(context.Background(), NewConstant(time.Millisecond), func(ctx context.Context) (bool, error) {
+			calls++
+			if calls == 2 {
+				return false, errFailed
+			}
+			return false, nil
+		})
+		if err != errFailed {
+			t.Errorf("Expected terminal error, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected polling to stop after the terminal failure, got %d calls", calls)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := Poll(ctx, NewConstant(time.Hour), func(ctx context.Context) (bool, error) {
+			cancel()
+			return false, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
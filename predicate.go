@@ -0,0 +1,102 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// Retryable reports whether an error returned by an operation should be
+// retried. Use it with RetryIf.
+type Retryable func(err error) bool
+
+// RetryOn returns a Retryable that matches errors for which errors.Is
+// reports a match with any of errs.
+//
+// Example:
+//
+//	RetryIf(RetryOn(ErrUnavailable, io.ErrUnexpectedEOF))
+func RetryOn(errs ...error) Retryable {
+	return func(err error) bool {
+		for _, target := range errs {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// DontRetryOn returns a Retryable that matches all errors except those for
+// which errors.Is reports a match with any of errs.
+//
+// Example:
+//
+//	RetryIf(DontRetryOn(ErrNotFound, ErrUnauthorized))
+func DontRetryOn(errs ...error) Retryable {
+	return Not(RetryOn(errs...))
+}
+
+// And returns a Retryable that matches errors matched by all of rs.
+// With no arguments, it matches every error.
+func And(rs ...Retryable) Retryable {
+	return func(err error) bool {
+		for _, r := range rs {
+			if !r(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Retryable that matches errors matched by any of rs.
+// With no arguments, it matches no error.
+func Or(rs ...Retryable) Retryable {
+	return func(err error) bool {
+		for _, r := range rs {
+			if r(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Retryable that matches errors not matched by r.
+func Not(r Retryable) Retryable {
+	return func(err error) bool {
+		return !r(err)
+	}
+}
+
+// DefaultTransientRetryable reports whether err is a common transient
+// failure of a file system or network operation:
+//   - a net.Error whose Timeout() is true
+//   - syscall.ECONNRESET or syscall.EAGAIN
+//   - io.ErrUnexpectedEOF
+//   - context.DeadlineExceeded
+//
+// Cancellation with context.Canceled is never considered transient, even
+// if err also matches one of the above.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op, RetryIf(DefaultTransientRetryable))
+func DefaultTransientRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EAGAIN) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,114 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryablePredicates(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	wrappedA := fmt.Errorf("wrapped: %w", errA)
+
+	tests := []struct {
+		name     string
+		r        Retryable
+		err      error
+		expected bool
+	}{
+		{"RetryOn match", RetryOn(errA, errB), errA, true},
+		{"RetryOn wrapped match", RetryOn(errA), wrappedA, true},
+		{"RetryOn no match", RetryOn(errA, errB), errC, false},
+		{"DontRetryOn match", DontRetryOn(errA), wrappedA, false},
+		{"DontRetryOn no match", DontRetryOn(errA), errC, true},
+		{"And all", And(RetryOn(errA), DontRetryOn(errB)), errA, true},
+		{"And one fails", And(RetryOn(errA, errB), DontRetryOn(errB)), errB, false},
+		{"And empty", And(), errC, true},
+		{"Or any", Or(RetryOn(errA), RetryOn(errB)), errB, true},
+		{"Or none", Or(RetryOn(errA), RetryOn(errB)), errC, false},
+		{"Or empty", Or(), errC, false},
+		{"Not", Not(RetryOn(errA)), errA, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r(tt.err); got != tt.expected {
+				t.Errorf("Expected %v for %v, got %v", tt.expected, tt.err, got)
+			}
+		})
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	calls := 0
+	err := RetryWithContext(context.Background(), NewConstant(0), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return errFatal
+	}, RetryIf(RetryOn(errTransient)))
+
+	if err != errFatal {
+		t.Errorf("Expected non-retryable error to be returned, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+
+	b := &testBreaker{threshold: 10}
+	calls = 0
+	err = RetryWithBreaker(context.Background(), NewConstant(time.Millisecond), b, func(context.Context) error {
+		calls++
+		return errFatal
+	}, RetryIf(DontRetryOn(errFatal)))
+	if err != errFatal || calls != 1 {
+		t.Errorf("Expected a single call returning the fatal error, got %d calls and %v", calls, err)
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDefaultTransientRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"net timeout", &net.OpError{Op: "read", Err: timeoutError{}}, true},
+		{"os deadline", os.ErrDeadlineExceeded, true},
+		{"connection reset", &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}, true},
+		{"try again", fmt.Errorf("write: %w", syscall.EAGAIN), true},
+		{"unexpected EOF", fmt.Errorf("decode: %w", io.ErrUnexpectedEOF), true},
+		{"context deadline", context.DeadlineExceeded, true},
+		{"context cancelled", context.Canceled, false},
+		{"cancelled and reset", errors.Join(context.Canceled, syscall.ECONNRESET), false},
+		{"EOF", io.EOF, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultTransientRetryable(tt.err); got != tt.expected {
+				t.Errorf("Expected %v for %v, got %v", tt.expected, tt.err, got)
+			}
+		})
+	}
+}
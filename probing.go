@@ -0,0 +1,274 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Probing implements a backoff strategy for endless reconnect loops:
+// delays grow exponentially up to a plateau and then stay there, except
+// that every few plateau delays a single short probe delay is inserted to
+// detect recovery quickly.
+//
+// Plain exponential backoff with a cap notices a recovered dependency only
+// after up to a full capped delay; the probes bound that latency without
+// giving up the low load of the plateau.
+type Probing struct {
+	options    *options
+	base       time.Duration // initial delay duration
+	factor     float64       // multiplier for each retry
+	plateau    time.Duration // delay at which growth stops
+	probeEvery int           // plateau delays between probes, 0 = no probes
+	probeDelay time.Duration // delay of a probe
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	current time.Duration // un-jittered growth delay of the last retry
+	hits    int           // plateau delays since the last probe
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewProbing creates a new probing backoff strategy.
+//
+// Parameters:
+//   - base: The initial delay duration for the first retry
+//   - factor: The multiplier applied until the plateau (must be > 1.0)
+//   - plateau: The delay at which growth stops
+//   - probeEvery: Number of plateau delays after which a probe is inserted
+//   - probeDelay: The delay of a probe
+//   - opts: Optional configuration functions
+//
+// Probe cadence: after every probeEvery delays at the plateau, the next
+// delay is probeDelay, after which counting starts over. The first delay
+// to reach the plateau counts as a plateau delay; probes do not. With
+// probeEvery 3, the sequence ends in plateau, plateau, plateau, probe,
+// plateau, plateau, plateau, probe, ...
+//
+// If factor <= 1.0, it defaults to 2.0. A plateau below base is raised to
+// base, and a probeEvery <= 0 disables probes. The sequence never stops
+// unless limits such as WithMaxRetries are configured.
+//
+// Example:
+//
+//	// 100ms, 200ms, ... up to 30s, with a 500ms probe after every 4th 30s
+//	p := NewProbing(100*time.Millisecond, 2.0, 30*time.Second, 4, 500*time.Millisecond)
+func NewProbing(base time.Duration, factor float64, plateau time.Duration, probeEvery int, probeDelay time.Duration, opts ...Option) *Probing {
+	if factor <= 1.0 {
+		factor = 2.0
+	}
+
+	o := applyOptions(opts)
+
+	p := &Probing{
+		options:    o,
+		base:       base,
+		factor:     factor,
+		plateau:    max(plateau, base),
+		probeEvery: max(probeEvery, 0),
+		probeDelay: probeDelay,
+		elapsed:    o.elapsedOffset,
+	}
+	p.skip(o.startSkip())
+
+	return p
+}
+
+// Next returns the next delay duration: the growing delay until the
+// plateau, then the plateau delay, or a probe delay if it is due.
+//
+// The returned delay is subject to jitter, min/max bounds and overflow
+// protection.
+//
+// Returns:
+//   - time.Duration: The calculated delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+func (p *Probing) Next() (time.Duration, bool) {
+	if p.options.outOfRetries(p.retries) {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	current, hits := p.step()
+	raw := current
+	if p.probeEvery > 0 && p.hits == p.probeEvery {
+		raw = p.probeDelay
+	}
+
+	d := p.options.applyJitter(raw, p.elapsed)
+	d = applyBounds(d, p.options.minInterval, p.options.maxAt(p.retries))
+	d = p.options.firstRange(d, p.retries)
+	d = p.options.scaled(d)
+	if p.options.maxElapsed > 0 && !p.options.free(p.retries) && d >= p.options.maxElapsed-p.options.spent(p.elapsed) {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	if !p.options.acquire() {
+		p.last, p.hasLast = 0, false
+		return 0, false
+	}
+
+	d = p.options.finish(d)
+	p.current, p.hits = current, hits
+	p.elapsed += p.options.charge(p.retries, d)
+	p.retries++
+	p.last, p.hasLast = d, true
+	return d, true
+}
+
+// NextContext is like Next, but returns (0, false) without advancing
+// towards the next probe if ctx is done.
+func (p *Probing) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, p)
+}
+
+// step returns the growth delay and plateau count after the next retry,
+// without modifying the state.
+func (p *Probing) step() (current time.Duration, hits int) {
+	if p.probeEvery > 0 && p.hits == p.probeEvery {
+		return p.current, 0 // probe
+	}
+
+	current = p.base
+	if p.retries > 0 {
+		current = min(mulDuration(p.current, p.factor), p.plateau)
+	}
+
+	if current >= p.plateau {
+		hits = p.hits + 1
+	}
+	return current, hits
+}
+
+// skip advances the growth state and probe cadence by n steps, as
+// WithRandomStart does at construction.
+func (p *Probing) skip(n int) {
+	for ; n > 0; n-- {
+		p.current, p.hits = p.step()
+		p.retries++
+	}
+}
+
+// JitterSpread returns the standard deviation of the next delay of p; see
+// Common methods.
+func (p *Probing) JitterSpread(n int) time.Duration {
+	return jitterSpread(p, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of p. Without jitter, it counts the growth steps, the plateau and
+// the probe delay; see Common methods.
+func (p *Probing) DistinctDelays(n int) int {
+	return distinctDelays(p, n)
+}
+
+// fork returns a copy of the probing backoff including its state.
+func (p *Probing) fork(mod func(*options)) Sequence {
+	f := *p
+	f.options = forkOptions(p.options, mod)
+	return &f
+}
+
+// Reset resets the probing backoff to its initial state.
+// This clears the retry count, the growth, the probe cadence and the
+// delay reported by Current, and restores the elapsed time to its initial
+// offset.
+func (p *Probing) Reset() {
+	if !p.options.allowReset() {
+		return
+	}
+	p.retries = 0
+	p.elapsed = p.options.elapsedOffset
+	p.current = 0
+	p.hits = 0
+	p.last = 0
+	p.hasLast = false
+	p.options.reset()
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (p *Probing) Current() (time.Duration, bool) {
+	return p.last, p.hasLast
+}
+
+// Attempts returns the number of delays returned since construction or
+// Reset, probes included.
+func (p *Probing) Attempts() int {
+	return p.retries
+}
+
+// Elapsed returns the sum of the delays returned so far, plus the offset
+// set with WithElapsedOffset.
+func (p *Probing) Elapsed() time.Duration {
+	return p.elapsed
+}
+
+// RemainingRetries returns the retries p has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (p *Probing) RemainingRetries() int {
+	return remainingRetries(p.options, p.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (p *Probing) budget() (limit, spent time.Duration) {
+	return p.options.maxElapsed, p.options.spent(p.elapsed)
+}
+
+// Remaining returns the elapsed budget p has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (p *Probing) Remaining() time.Duration {
+	return remaining(p)
+}
+
+// Derive returns a probing backoff that starts over from the base delay,
+// but is charged with the elapsed time of p, so that a nested operation
+// stops at the same deadline.
+func (p *Probing) Derive() *Probing {
+	return derive(p, p)
+}
+
+// Clone returns an independent copy of p, including its growth and the
+// plateau delays since the last probe.
+func (p *Probing) Clone() *Probing {
+	return clone(p)
+}
+
+// Iter returns an iterator over the next delays of p, see Iterate. Without
+// WithMaxRetries or WithMaxElapsed the loop must break on its own.
+func (p *Probing) Iter() iter.Seq[time.Duration] {
+	return Iterate(p)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of p ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (p *Probing) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, p, p.options, now)
+}
+
+// Describe returns the configuration of the probing backoff.
+func (p *Probing) Describe() Description {
+	return p.options.describe(Description{
+		Kind:       KindProbing,
+		Base:       p.base,
+		Factor:     p.factor,
+		Plateau:    p.plateau,
+		ProbeEvery: p.probeEvery,
+		ProbeDelay: p.probeDelay,
+	})
+}
+
+// WithOverrides returns a new probing backoff with the same base, factor,
+// plateau, probes and options, plus opts applied on top, starting from its
+// initial state.
+func (p *Probing) WithOverrides(opts ...Option) Sequence {
+	return NewProbing(p.base, p.factor, p.plateau, p.probeEvery, p.probeDelay, p.options.with(opts)...)
+}
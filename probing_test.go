@@ -0,0 +1,106 @@
+package backoff
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestProbing(t *testing.T) {
+	ms := time.Millisecond
+
+	t.Run("grows to the plateau and probes", func(t *testing.T) {
+		p := NewProbing(100*ms, 2.0, 500*ms, 3, 50*ms)
+
+		want := []time.Duration{
+			100 * ms, 200 * ms, 400 * ms,
+			500 * ms, 500 * ms, 500 * ms, 50 * ms,
+			500 * ms, 500 * ms, 500 * ms, 50 * ms,
+			500 * ms,
+		}
+		var got []time.Duration
+		for range want {
+			d, ok := p.Next()
+			if !ok {
+				t.Fatal("Next() should never return false without limits")
+			}
+			got = append(got, d)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("probe cadence", func(t *testing.T) {
+		const probeEvery = 4
+		p := NewProbing(10*ms, 3.0, 90*ms, probeEvery, ms)
+
+		plateau := 0
+		for i := 0; i < 200; i++ {
+			d, _ := p.Next()
+			switch d {
+			case 90 * ms:
+				plateau++
+				if plateau > probeEvery {
+					t.Fatalf("Call %d: missed probe after %d plateau delays", i+1, probeEvery)
+				}
+			case ms:
+				if plateau != probeEvery {
+					t.Fatalf("Call %d: probe after %d plateau delays, expected %d", i+1, plateau, probeEvery)
+				}
+				plateau = 0
+			}
+		}
+	})
+
+	t.Run("no probes", func(t *testing.T) {
+		p := NewProbing(100*ms, 2.0, 200*ms, 0, 10*ms)
+		for i := 0; i < 20; i++ {
+			if d, _ := p.Next(); d == 10*ms {
+				t.Fatalf("Call %d: unexpected probe with probeEvery 0", i+1)
+			}
+		}
+	})
+
+	t.Run("reset restarts growth and cadence", func(t *testing.T) {
+		p := NewProbing(100*ms, 2.0, 200*ms, 2, 10*ms)
+		for i := 0; i < 3; i++ {
+			p.Next()
+		}
+		p.Reset()
+
+		want := []time.Duration{100 * ms, 200 * ms, 200 * ms, 10 * ms}
+		for i, w := range want {
+			if d, _ := p.Next(); d != w {
+				t.Errorf("Call %d after Reset(): expected %v, got %v", i+1, w, d)
+			}
+		}
+	})
+
+	t.Run("honors limits", func(t *testing.T) {
+		p := NewProbing(100*ms, 2.0, 200*ms, 2, 10*ms, WithMaxRetries(5))
+		n := 0
+		for ; n < 10; n++ {
+			if _, ok := p.Next(); !ok {
+				break
+			}
+		}
+		if n != 5 {
+			t.Errorf("Expected 5 delays, got %d", n)
+		}
+	})
+
+	t.Run("stops at max elapsed", func(t *testing.T) {
+		// Delays of 100ms and 200ms reach the 300ms budget exactly.
+		p := NewProbing(100*ms, 2.0, 200*ms, 2, 10*ms, WithMaxElapsed(300*ms))
+		n := 0
+		for ; n < 10; n++ {
+			if _, ok := p.Next(); !ok {
+				break
+			}
+		}
+		if n != 1 {
+			t.Errorf("Expected 1 delay, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package backoff
+
+import "time"
+
+// AlignTo returns the delay from now until resetAt, e.g. the time a
+// server-reported rate limit window (such as X-RateLimit-Reset) refreshes.
+// Using it instead of the computed backoff makes the next attempt resume
+// exactly when the quota is available again. WithRateLimitReset does so
+// in the retry helpers.
+//
+// Returns 0 if resetAt is not after now.
+//
+// Example:
+//
+//	reset := time.Unix(resetUnix, 0)
+//	delay, ok := b.Next()
+//	if !ok {
+//		return err
+//	}
+//	if d := AlignTo(reset, time.Now()); d > 0 {
+//		delay = d
+//	}
+//	time.Sleep(delay)
+func AlignTo(resetAt, now time.Time) time.Duration {
+	if !resetAt.After(now) {
+		return 0
+	}
+	return resetAt.Sub(now)
+}
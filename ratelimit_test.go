@@ -0,0 +1,28 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlignTo(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		resetAt  time.Time
+		expected time.Duration
+	}{
+		{"future reset", now.Add(3 * time.Second), 3 * time.Second},
+		{"reset now", now, 0},
+		{"past reset", now.Add(-time.Minute), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AlignTo(tt.resetAt, now); got != tt.expected {
+				t.Errorf("AlignTo(%v, %v) = %v, expected %v", tt.resetAt, now, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package backoff
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownPolicy is returned by Get for names that were not registered.
+var ErrUnknownPolicy = errors.New("backoff: unknown policy")
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]func() Sequence)
+)
+
+// Register makes a backoff policy available by name, so that it can be
+// defined and reviewed once and looked up with Get throughout a large
+// application. The factory is called on every Get and must return a fresh
+// instance each time.
+//
+// Like database/sql.Register, Register is meant to be called during
+// initialization and panics if factory is nil or name is already
+// registered.
+//
+// Example:
+//
+//	func init() {
+//		backoff.Register("db", func() backoff.Sequence {
+//			return backoff.NewExponential(50*time.Millisecond, 2.0,
+//				backoff.WithMaxRetries(5), backoff.WithJitter())
+//		})
+//	}
+func Register(name string, factory func() Sequence) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("backoff: Register factory is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("backoff: Register called twice for policy " + name)
+	}
+	registry[name] = factory
+}
+
+// Get returns a new instance of the policy registered under name.
+// Returns an error wrapping ErrUnknownPolicy if no policy was registered
+// under that name.
+func Get(name string) (Sequence, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownPolicy, name)
+	}
+	return factory(), nil
+}
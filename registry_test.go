@@ -0,0 +1,58 @@
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	// The registry is global, so register only once with -count > 1
+	if _, err := Get("test-http"); err != nil {
+		Register("test-http", func() Sequence {
+			return NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(3))
+		})
+	}
+
+	t.Run("fresh instance per Get", func(t *testing.T) {
+		a, err := Get("test-http")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		a.Next()
+		a.Next()
+
+		b, err := Get("test-http")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if d, _ := b.Next(); d != 100*time.Millisecond {
+			t.Errorf("Expected a fresh instance starting at 100ms, got %v", d)
+		}
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		s, err := Get("test-missing")
+		if !errors.Is(err, ErrUnknownPolicy) || s != nil {
+			t.Errorf("Expected ErrUnknownPolicy, got (%v, %v)", s, err)
+		}
+	})
+
+	t.Run("duplicate registration", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Register to panic on a duplicate name")
+			}
+		}()
+		Register("test-http", func() Sequence { return NewConstant(time.Second) })
+	})
+
+	t.Run("nil factory", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Register to panic on a nil factory")
+			}
+		}()
+		Register("test-nil", nil)
+	})
+}
@@ -0,0 +1,511 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// ErrCircuitOpen is returned by RetryWithBreaker when the breaker rejects
+// an attempt.
+var ErrCircuitOpen = errors.New("backoff: circuit open")
+
+// exhausted wraps the last error from op when a helper gives up because
+// the sequence is exhausted. The result has two layers: errors.Is(err,
+// ErrExhausted) reports that the helper gave up, while errors.Is and
+// errors.As still match lastErr and anything it wraps, e.g.:
+//
+//	backoff: retries exhausted: dial tcp: connection refused
+func exhausted(lastErr error) error {
+	return fmt.Errorf("%w: %w", ErrExhausted, lastErr)
+}
+
+// PanicError is returned by the retry helpers configured with WithRecover
+// when the operation panics.
+type PanicError struct {
+	Value any    // value passed to panic
+	Stack []byte // stack trace of the panicking goroutine
+}
+
+// Error returns a description of the recovered panic.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("backoff: operation panicked: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error, or nil otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Sleeper defines how the retry helpers wait between attempts.
+type Sleeper interface {
+	// Sleep waits for the duration d or until ctx is done, whichever
+	// happens first. Returns the context error if ctx was done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// RetryOption is a function type used to configure the retry helpers.
+type RetryOption func(*retryOptions)
+
+// retryOptions holds configuration for the retry helpers.
+type retryOptions struct {
+	sleeper   Sleeper                               // waits between attempts
+	recover   bool                                  // recover panics in the operation
+	retryable Retryable                             // nil = retry on every error
+	loadGate  func() bool                           // nil = never defer for load
+	drift     func(requested, actual time.Duration) // nil = not observed
+	resetAt   func(err error) (time.Time, bool)     // nil = no rate limit reset
+	onError   func(err error)                       // nil = errors of Every are dropped
+}
+
+// WithSleeper sets the Sleeper used by the retry helpers to wait between
+// attempts. If not specified, a real timer is used.
+func WithSleeper(s Sleeper) RetryOption {
+	return func(o *retryOptions) {
+		o.sleeper = s
+	}
+}
+
+// WithDryRun makes the retry helpers skip the wait between attempts.
+// The sequence is still advanced, so attempt and elapsed limits apply as
+// usual, but the whole retry loop runs instantly. This is intended for
+// testing business logic that uses the retry helpers.
+//
+// Unlike a fake Clock, which simulates the passage of time, dry-run
+// does not wait at all.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op, WithDryRun())
+func WithDryRun() RetryOption {
+	return WithSleeper(noopSleeper{})
+}
+
+// WithRecover makes the retry helpers recover panics in the operation and
+// treat them as a failed attempt with a *PanicError. The attempt is retried
+// like any other failure; if retries are exhausted, the *PanicError is
+// returned. By default panics propagate.
+//
+// This is useful in worker pools where one bad item should not crash the
+// worker.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, handle, WithRecover())
+//	var perr *PanicError
+//	if errors.As(err, &perr) {
+//		log.Printf("handler panicked: %v\n%s", perr.Value, perr.Stack)
+//	}
+func WithRecover() RetryOption {
+	return func(o *retryOptions) {
+		o.recover = true
+	}
+}
+
+// RetryIf makes the retry helpers retry only errors for which r returns
+// true; any other error is returned immediately. Combine it with the
+// predicate builders RetryOn, DontRetryOn, And, Or, and Not.
+// Poll treats every error as terminal and ignores this option.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op,
+//		RetryIf(And(RetryOn(ErrUnavailable, ErrTimeout), Not(RetryOn(context.Canceled)))))
+func RetryIf(r Retryable) RetryOption {
+	return func(o *retryOptions) {
+		o.retryable = r
+	}
+}
+
+// WithLoadGate makes the retry helpers consult gate before each retry.
+// If gate returns false, e.g. because a shared load gauge reports that the
+// system is overloaded, the helper waits one extra interval, equal to the
+// delay it just waited, before attempting. The extra wait does not count
+// against the sequence's retry limit.
+//
+// This adds at most one interval of latency per retry. Cancelling the
+// context interrupts the extra wait like any other.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op,
+//		WithLoadGate(func() bool { return load.Load() < 0.8 }))
+func WithLoadGate(gate func() bool) RetryOption {
+	return func(o *retryOptions) {
+		o.loadGate = gate
+	}
+}
+
+// WithDriftObserver makes the retry helpers call fn after every completed
+// wait with the requested delay and the time actually spent, measured on
+// the monotonic clock around the Sleeper. Under load, timers fire late;
+// this surfaces that inaccuracy, e.g. for SLO verification. Waits
+// interrupted by the context are not reported.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op,
+//		WithDriftObserver(func(requested, actual time.Duration) {
+//			driftHistogram.Observe((actual - requested).Seconds())
+//		}))
+func WithDriftObserver(fn func(requested, actual time.Duration)) RetryOption {
+	return func(o *retryOptions) {
+		o.drift = fn
+	}
+}
+
+// WithRateLimitReset makes the retry helpers wait until the reset time of
+// a server-reported rate limit window, e.g. from an X-RateLimit-Reset
+// header, instead of the delay from the sequence. resetAt extracts the
+// reset time from the error of the failed attempt, or reports false if it
+// has none. The sequence is still advanced, so its limits apply as usual.
+// A reset time that is not in the future keeps the computed delay.
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, op,
+//		WithRateLimitReset(func(err error) (time.Time, bool) {
+//			var rl *RateLimitError
+//			if !errors.As(err, &rl) {
+//				return time.Time{}, false
+//			}
+//			return rl.Reset, true
+//		}))
+func WithRateLimitReset(resetAt func(err error) (time.Time, bool)) RetryOption {
+	return func(o *retryOptions) {
+		o.resetAt = resetAt
+	}
+}
+
+// WithErrorHandler makes Every call fn with the error of every failed
+// run of its task. The retry helpers ignore it, since they return the
+// error instead.
+//
+// Example:
+//
+//	err := Every(ctx, p, sync,
+//		WithErrorHandler(func(err error) {
+//			syncFailures.Inc()
+//		}))
+func WithErrorHandler(fn func(err error)) RetryOption {
+	return func(o *retryOptions) {
+		o.onError = fn
+	}
+}
+
+// applyRetryOptions creates a new retryOptions struct with default values
+// and applies all provided option functions.
+func applyRetryOptions(opts []RetryOption) *retryOptions {
+	o := &retryOptions{
+		sleeper: timerSleeper{},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// call runs fn, converting a panic into a *PanicError if WithRecover is set.
+func (o *retryOptions) call(fn func() error) (err error) {
+	if o.recover {
+		defer func() {
+			if v := recover(); v != nil {
+				err = &PanicError{Value: v, Stack: debug.Stack()}
+			}
+		}()
+	}
+	return fn()
+}
+
+// shouldRetry reports whether err is retryable according to RetryIf.
+func (o *retryOptions) shouldRetry(err error) bool {
+	return o.retryable == nil || o.retryable(err)
+}
+
+// delay returns the wait after an attempt that failed with err: d, or the
+// time until the rate limit reset reported by WithRateLimitReset.
+func (o *retryOptions) delay(err error, d time.Duration) time.Duration {
+	if o.resetAt == nil {
+		return d
+	}
+	if resetAt, ok := o.resetAt(err); ok {
+		if aligned := AlignTo(resetAt, time.Now()); aligned > 0 {
+			return aligned
+		}
+	}
+	return d
+}
+
+// wait sleeps for d before the next attempt, plus another d if the load
+// gate is closed. Returns the time waited, counting only completed sleeps.
+func (o *retryOptions) wait(ctx context.Context, d time.Duration) (time.Duration, error) {
+	if err := o.sleep(ctx, d); err != nil {
+		return 0, err
+	}
+	if o.loadGate != nil && !o.loadGate() {
+		if err := o.sleep(ctx, d); err != nil {
+			return d, err
+		}
+		return addDuration(d, d), nil
+	}
+	return d, nil
+}
+
+// sleep sleeps for d using the Sleeper, reporting the drift if observed.
+func (o *retryOptions) sleep(ctx context.Context, d time.Duration) error {
+	if o.drift == nil {
+		return o.sleeper.Sleep(ctx, d)
+	}
+
+	start := time.Now()
+	if err := o.sleeper.Sleep(ctx, d); err != nil {
+		return err
+	}
+	o.drift(d, time.Since(start))
+	return nil
+}
+
+// Breaker defines the interface for a circuit breaker consulted by
+// RetryWithBreaker. The package does not ship an implementation; any
+// breaker exposing these methods can be plugged in.
+type Breaker interface {
+	// Allow reports whether an attempt may be made. Returning false
+	// means the circuit is open and the dependency should not be called.
+	Allow() bool
+
+	// RecordSuccess records a successful attempt.
+	RecordSuccess()
+
+	// RecordFailure records a failed attempt.
+	RecordFailure()
+}
+
+// RetryWithBreaker calls op until it succeeds, the sequence is exhausted,
+// the context is cancelled, or the breaker opens. Before each attempt the
+// breaker's Allow() is checked, and the outcome of every attempt is recorded
+// with RecordSuccess() or RecordFailure().
+//
+// Like RetryWithContext, each call to op receives a context carrying the
+// retry sequence ID and the attempt number.
+//
+// Returns:
+//   - nil if op succeeded
+//   - ErrCircuitOpen if the breaker rejected an attempt; when op has failed
+//     before, the error also wraps the last error from op
+//   - an error wrapping both ErrExhausted and the last error from op if
+//     the sequence is exhausted; see exhausted
+//   - the context error if ctx is cancelled
+//
+// Example:
+//
+//	b := NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(5))
+//	err := RetryWithBreaker(ctx, b, breaker, func(ctx context.Context) error {
+//		return client.Ping(ctx)
+//	})
+//	if errors.Is(err, ErrCircuitOpen) {
+//		// dependency is down, fail fast
+//	}
+func RetryWithBreaker(ctx context.Context, s Sequence, b Breaker, op func(context.Context) error, opts ...RetryOption) error {
+	o := applyRetryOptions(opts)
+	ctx = withRetryID(ctx, newRetryID())
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !b.Allow() {
+			if lastErr != nil {
+				return fmt.Errorf("%w: %w", ErrCircuitOpen, lastErr)
+			}
+			return ErrCircuitOpen
+		}
+
+		actx := withAttempt(ctx, attempt)
+		lastErr = o.call(func() error { return op(actx) })
+		if lastErr == nil {
+			b.RecordSuccess()
+			return nil
+		}
+		b.RecordFailure()
+
+		if !o.shouldRetry(lastErr) {
+			return lastErr
+		}
+
+		d, ok := s.Next()
+		if !ok {
+			return exhausted(lastErr)
+		}
+
+		if _, err := o.wait(ctx, o.delay(lastErr, d)); err != nil {
+			return err
+		}
+	}
+}
+
+// Retry calls fn until it succeeds, the sequence is exhausted, or the
+// context is cancelled, sleeping for the delay returned by s.Next()
+// between attempts. A sleep is cut short when ctx is done. It is the
+// simplest of the retry helpers; use RetryWithContext if fn needs the
+// context.
+//
+// Returns:
+//   - nil if fn succeeded
+//   - an error wrapping both ErrExhausted and the last error from fn if
+//     the sequence is exhausted; see exhausted
+//   - the context error if ctx is cancelled
+//
+// Example:
+//
+//	b := NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(5))
+//	err := Retry(ctx, b, func() error {
+//		return client.Ping()
+//	})
+func Retry(ctx context.Context, s Sequence, fn func() error, opts ...RetryOption) error {
+	return RetryWithContext(ctx, s, func(context.Context) error {
+		return fn()
+	}, opts...)
+}
+
+// RetryWithData is like Retry for operations that produce a value, such
+// as the body of an HTTP response. It returns the value of the successful
+// call to fn, or the zero value of T and the same error as Retry.
+//
+// Example:
+//
+//	body, err := RetryWithData(ctx, b, func() ([]byte, error) {
+//		return fetch(url)
+//	})
+func RetryWithData[T any](ctx context.Context, s Sequence, fn func() (T, error), opts ...RetryOption) (T, error) {
+	var v T
+	err := Retry(ctx, s, func() error {
+		var err error
+		v, err = fn()
+		return err
+	}, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// RetryWithContext calls op until it succeeds, the sequence is exhausted,
+// or the context is cancelled, sleeping for the delay returned by s.Next()
+// between attempts.
+//
+// Each call to op receives a context derived from ctx that carries a retry
+// sequence ID and the attempt number, retrievable with RetryIDFromContext
+// and AttemptFromContext. The ID is unique per RetryWithContext call and
+// stable across its attempts, which makes it easy to correlate the logs of
+// a single retry sequence.
+//
+// Returns:
+//   - nil if op succeeded
+//   - an error wrapping both ErrExhausted and the last error from op if
+//     the sequence is exhausted; see exhausted
+//   - the context error if ctx is cancelled
+//
+// Example:
+//
+//	err := RetryWithContext(ctx, b, func(ctx context.Context) error {
+//		id, _ := RetryIDFromContext(ctx)
+//		n, _ := AttemptFromContext(ctx)
+//		log.Printf("retry=%s attempt=%d fetching", id, n)
+//		return fetch(ctx)
+//	})
+func RetryWithContext(ctx context.Context, s Sequence, op func(context.Context) error, opts ...RetryOption) error {
+	_, err := RetryResult(ctx, s, op, opts...)
+	return err
+}
+
+// Result describes how a retried operation went, as returned by
+// RetryResult.
+type Result struct {
+	Attempts  int           // number of calls to the operation
+	TotalWait time.Duration // time waited between the attempts
+	Succeeded bool          // whether the operation succeeded
+}
+
+// RetryResult is like RetryWithContext, but also reports how many
+// attempts and how much waiting it took, e.g. to record the distribution
+// of the time to the first success. The result is filled in whether or not
+// op succeeds, including when ctx is cancelled.
+//
+// TotalWait is the sum of the waits between attempts, as requested from
+// the Sleeper, including the extra waits of a closed load gate; it does
+// not include the time spent in op.
+//
+// Example:
+//
+//	res, err := RetryResult(ctx, b, fetch)
+//	attemptsHistogram.Observe(float64(res.Attempts))
+//	if res.Succeeded {
+//		waitHistogram.Observe(res.TotalWait.Seconds())
+//	}
+func RetryResult(ctx context.Context, s Sequence, op func(context.Context) error, opts ...RetryOption) (Result, error) {
+	o := applyRetryOptions(opts)
+	ctx = withRetryID(ctx, newRetryID())
+
+	var res Result
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		actx := withAttempt(ctx, attempt)
+		res.Attempts++
+		err := o.call(func() error { return op(actx) })
+		if err == nil {
+			res.Succeeded = true
+			return res, nil
+		}
+
+		if !o.shouldRetry(err) {
+			return res, err
+		}
+
+		d, ok := s.Next()
+		if !ok {
+			return res, exhausted(err)
+		}
+
+		waited, err := o.wait(ctx, o.delay(err, d))
+		res.TotalWait += waited
+		if err != nil {
+			return res, err
+		}
+	}
+}
+
+// timerSleeper implements Sleeper using a real timer.
+type timerSleeper struct{}
+
+// Sleep waits for the duration d or until ctx is done, whichever happens
+// first. Returns the context error if ctx was done before d elapsed.
+func (timerSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// noopSleeper implements Sleeper without waiting.
+type noopSleeper struct{}
+
+// Sleep returns immediately with the context error, if any.
+func (noopSleeper) Sleep(ctx context.Context, _ time.Duration) error {
+	return ctx.Err()
+}
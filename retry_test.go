@@ -0,0 +1,631 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// testBreaker is a Breaker that opens after a fixed number of failures.
+type testBreaker struct {
+	threshold int
+	failures  int
+	successes int
+}
+
+func (b *testBreaker) Allow() bool {
+	return b.failures < b.threshold
+}
+
+func (b *testBreaker) RecordSuccess() {
+	b.successes++
+}
+
+func (b *testBreaker) RecordFailure() {
+	b.failures++
+}
+
+func TestRetryWithBreaker(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("succeeds after failures", func(t *testing.T) {
+		b := &testBreaker{threshold: 10}
+		calls := 0
+		err := RetryWithBreaker(context.Background(), NewConstant(time.Millisecond), b, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errFail
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+		if b.failures != 2 || b.successes != 1 {
+			t.Errorf("Expected 2 failures and 1 success recorded, got %d and %d", b.failures, b.successes)
+		}
+	})
+
+	t.Run("breaker opens", func(t *testing.T) {
+		b := &testBreaker{threshold: 2}
+		calls := 0
+		err := RetryWithBreaker(context.Background(), NewConstant(time.Millisecond), b, func(context.Context) error {
+			calls++
+			return errFail
+		})
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Expected ErrCircuitOpen, got %v", err)
+		}
+		if !errors.Is(err, errFail) {
+			t.Errorf("Expected error to wrap the last operation error, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls before the circuit opened, got %d", calls)
+		}
+	})
+
+	t.Run("breaker already open", func(t *testing.T) {
+		b := &testBreaker{threshold: 0}
+		err := RetryWithBreaker(context.Background(), NewConstant(time.Millisecond), b, func(context.Context) error {
+			t.Error("op should not be called when the circuit is open")
+			return nil
+		})
+		if err != ErrCircuitOpen {
+			t.Errorf("Expected ErrCircuitOpen, got %v", err)
+		}
+	})
+
+	t.Run("sequence exhausted", func(t *testing.T) {
+		b := &testBreaker{threshold: 10}
+		calls := 0
+		err := RetryWithBreaker(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(2)), b, func(context.Context) error {
+			calls++
+			return errFail
+		})
+		if !errors.Is(err, ErrExhausted) || !errors.Is(err, errFail) {
+			t.Errorf("Expected ErrExhausted wrapping the last operation error, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls (1 + 2 retries), got %d", calls)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		b := &testBreaker{threshold: 10}
+		err := RetryWithBreaker(ctx, NewConstant(time.Hour), b, func(context.Context) error {
+			cancel()
+			return errFail
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("correlation id and attempt", func(t *testing.T) {
+		b := &testBreaker{threshold: 10}
+		var ids []string
+		var attempts []int
+		err := RetryWithBreaker(context.Background(), NewConstant(time.Millisecond), b, func(ctx context.Context) error {
+			id, ok := RetryIDFromContext(ctx)
+			if !ok {
+				t.Error("Expected a retry ID in the context")
+			}
+			n, ok := AttemptFromContext(ctx)
+			if !ok {
+				t.Error("Expected an attempt number in the context")
+			}
+			ids = append(ids, id)
+			attempts = append(attempts, n)
+			if n < 3 {
+				return errFail
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+
+		for i, n := range attempts {
+			if n != i+1 {
+				t.Errorf("Expected attempt %d, got %d", i+1, n)
+			}
+		}
+		for _, id := range ids {
+			if id != ids[0] {
+				t.Errorf("Expected a stable retry ID %q, got %q", ids[0], id)
+			}
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("fails then succeeds", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), NewConstant(time.Millisecond), func() error {
+			if calls++; calls <= 3 {
+				return errFail
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+		if calls != 4 {
+			t.Errorf("Expected 4 calls, got %d", calls)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(2)), func() error {
+			calls++
+			return errFail
+		})
+		if !errors.Is(err, ErrExhausted) || !errors.Is(err, errFail) {
+			t.Errorf("Expected ErrExhausted wrapping %v, got %v", errFail, err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("cancelled mid-sleep", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		start := time.Now()
+		err := Retry(ctx, NewConstant(time.Hour), func() error { return errFail })
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected the sleep to be cut short, took %v", elapsed)
+		}
+	})
+}
+
+func TestRetryWithData(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("returns the value", func(t *testing.T) {
+		calls := 0
+		v, err := RetryWithData(context.Background(), NewConstant(time.Millisecond), func() (int, error) {
+			if calls++; calls < 3 {
+				return -1, errFail
+			}
+			return 42, nil
+		})
+		if err != nil || v != 42 {
+			t.Errorf("Expected (42, nil), got (%d, %v)", v, err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		v, err := RetryWithData(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(2)), func() (int, error) {
+			return 7, errFail
+		})
+		if !errors.Is(err, ErrExhausted) || !errors.Is(err, errFail) {
+			t.Errorf("Expected ErrExhausted wrapping %v, got %v", errFail, err)
+		}
+		if v != 0 {
+			t.Errorf("Expected the zero value on failure, got %d", v)
+		}
+	})
+}
+
+func TestRetryWithContext(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("correlation id and attempt", func(t *testing.T) {
+		var ids []string
+		var attempts []int
+		err := RetryWithContext(context.Background(), NewConstant(time.Millisecond), func(ctx context.Context) error {
+			id, ok := RetryIDFromContext(ctx)
+			if !ok {
+				t.Error("Expected a retry ID in the context")
+			}
+			n, ok := AttemptFromContext(ctx)
+			if !ok {
+				t.Error("Expected an attempt number in the context")
+			}
+			ids = append(ids, id)
+			attempts = append(attempts, n)
+			if n < 3 {
+				return errFail
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+
+		for i, n := range attempts {
+			if n != i+1 {
+				t.Errorf("Expected attempt %d, got %d", i+1, n)
+			}
+		}
+		for _, id := range ids {
+			if id != ids[0] {
+				t.Errorf("Expected a stable retry ID %q, got %q", ids[0], id)
+			}
+		}
+		if len(ids[0]) != 36 {
+			t.Errorf("Expected a UUID formatted ID, got %q", ids[0])
+		}
+	})
+
+	t.Run("unique id per call", func(t *testing.T) {
+		var ids []string
+		for i := 0; i < 2; i++ {
+			_ = RetryWithContext(context.Background(), NewConstant(0), func(ctx context.Context) error {
+				id, _ := RetryIDFromContext(ctx)
+				ids = append(ids, id)
+				return nil
+			})
+		}
+		if ids[0] == ids[1] {
+			t.Errorf("Expected different IDs per call, got %q twice", ids[0])
+		}
+	})
+
+	t.Run("sequence exhausted", func(t *testing.T) {
+		err := RetryWithContext(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(1)), func(ctx context.Context) error {
+			return errFail
+		})
+		if !errors.Is(err, ErrExhausted) || !errors.Is(err, errFail) {
+			t.Errorf("Expected ErrExhausted wrapping the last operation error, got %v", err)
+		}
+	})
+
+	t.Run("exhaustion keeps the error chain", func(t *testing.T) {
+		opErr := &net.OpError{Op: "dial", Err: errFail}
+		err := RetryWithContext(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(1)), func(ctx context.Context) error {
+			return opErr
+		})
+		if !errors.Is(err, ErrExhausted) {
+			t.Errorf("Expected errors.Is(err, ErrExhausted), got %v", err)
+		}
+		var target *net.OpError
+		if !errors.As(err, &target) || target != opErr {
+			t.Errorf("Expected errors.As to find the operation error, got %v", err)
+		}
+		if !errors.Is(err, errFail) {
+			t.Error("Expected errors.Is to see through the operation error")
+		}
+	})
+
+	t.Run("non-retryable errors are not wrapped", func(t *testing.T) {
+		err := RetryWithContext(context.Background(), NewConstant(time.Millisecond), func(ctx context.Context) error {
+			return errFail
+		}, RetryIf(func(error) bool { return false }))
+		if err != errFail || errors.Is(err, ErrExhausted) {
+			t.Errorf("Expected the bare operation error, got %v", err)
+		}
+	})
+
+	t.Run("no values outside RetryWithContext", func(t *testing.T) {
+		if _, ok := RetryIDFromContext(context.Background()); ok {
+			t.Error("Expected no retry ID in a plain context")
+		}
+		if _, ok := AttemptFromContext(context.Background()); ok {
+			t.Error("Expected no attempt number in a plain context")
+		}
+	})
+}
+
+func TestRetryResult(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("success", func(t *testing.T) {
+		var sleeps sleepLog
+		calls := 0
+		res, err := RetryResult(context.Background(), NewExponential(10*time.Millisecond, 2.0),
+			func(context.Context) error {
+				if calls++; calls < 4 {
+					return errFail
+				}
+				return nil
+			},
+			WithSleeper(&sleeps))
+		if err != nil {
+			t.Fatalf("Expected success, got %v", err)
+		}
+
+		want := Result{Attempts: 4, TotalWait: 70 * time.Millisecond, Succeeded: true}
+		if res != want {
+			t.Errorf("Expected %+v, got %+v", want, res)
+		}
+		if res.Attempts != calls {
+			t.Errorf("Expected %d attempts as made, got %d", calls, res.Attempts)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		res, err := RetryResult(context.Background(), NewConstant(10*time.Millisecond, WithMaxRetries(2)),
+			func(context.Context) error { return errFail },
+			WithSleeper(&sleepLog{}))
+		if !errors.Is(err, ErrExhausted) {
+			t.Fatalf("Expected ErrExhausted, got %v", err)
+		}
+
+		want := Result{Attempts: 3, TotalWait: 20 * time.Millisecond}
+		if res != want {
+			t.Errorf("Expected %+v, got %+v", want, res)
+		}
+	})
+
+	t.Run("load gate waits are counted", func(t *testing.T) {
+		gates := []bool{false, true}
+		calls := 0
+		res, _ := RetryResult(context.Background(), NewConstant(10*time.Millisecond),
+			func(context.Context) error {
+				if calls++; calls < 3 {
+					return errFail
+				}
+				return nil
+			},
+			WithSleeper(&sleepLog{}),
+			WithLoadGate(func() bool {
+				open := gates[0]
+				gates = gates[1:]
+				return open
+			}))
+		if res.TotalWait != 30*time.Millisecond {
+			t.Errorf("Expected 30ms including the deferred wait, got %v", res.TotalWait)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		res, err := RetryResult(ctx, NewConstant(10*time.Millisecond),
+			func(context.Context) error {
+				cancel()
+				return errFail
+			},
+			WithSleeper(&sleepLog{}))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+		if want := (Result{Attempts: 1}); res != want {
+			t.Errorf("Expected %+v, got %+v", want, res)
+		}
+	})
+}
+
+func TestWithDryRun(t *testing.T) {
+	t.Run("does not sleep", func(t *testing.T) {
+		calls := 0
+		start := time.Now()
+		err := RetryWithContext(context.Background(), NewConstant(time.Hour, WithMaxRetries(3)), func(ctx context.Context) error {
+			calls++
+			return errors.New("fail")
+		}, WithDryRun())
+		if err == nil {
+			t.Fatal("Expected an error after exhausting retries")
+		}
+		if calls != 4 {
+			t.Errorf("Expected 4 calls (1 + 3 retries), got %d", calls)
+		}
+		if time.Since(start) > time.Second {
+			t.Errorf("Dry run should not sleep, took %v", time.Since(start))
+		}
+	})
+
+	t.Run("respects elapsed limit", func(t *testing.T) {
+		calls := 0
+		b := &testBreaker{threshold: 100}
+		_ = RetryWithBreaker(context.Background(), NewConstant(time.Minute, WithMaxElapsed(3*time.Minute)), b, func(context.Context) error {
+			calls++
+			return errors.New("fail")
+		}, WithDryRun())
+		if calls != 4 {
+			t.Errorf("Expected 4 calls (1 + 3 retries within the budget), got %d", calls)
+		}
+	})
+}
+
+func TestWithRecover(t *testing.T) {
+	t.Run("retries after panic", func(t *testing.T) {
+		calls := 0
+		err := RetryWithContext(context.Background(), NewConstant(0), func(ctx context.Context) error {
+			calls++
+			if calls == 1 {
+				panic("boom")
+			}
+			return nil
+		}, WithRecover())
+		if err != nil {
+			t.Fatalf("Expected success after recovered panic, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", calls)
+		}
+	})
+
+	t.Run("returns PanicError when exhausted", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		b := &testBreaker{threshold: 10}
+		err := RetryWithBreaker(context.Background(), NewConstant(0, WithMaxRetries(1)), b, func(context.Context) error {
+			panic(errBoom)
+		}, WithRecover())
+
+		var perr *PanicError
+		if !errors.As(err, &perr) {
+			t.Fatalf("Expected *PanicError, got %v", err)
+		}
+		if perr.Value != errBoom {
+			t.Errorf("Expected recovered value %v, got %v", errBoom, perr.Value)
+		}
+		if len(perr.Stack) == 0 {
+			t.Error("Expected a stack trace")
+		}
+		if !errors.Is(err, errBoom) {
+			t.Error("Expected PanicError to unwrap to the panicked error")
+		}
+		if b.failures != 2 {
+			t.Errorf("Expected panics to be recorded as failures, got %d", b.failures)
+		}
+	})
+
+	t.Run("propagates by default", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic to propagate without WithRecover")
+			}
+		}()
+		_ = RetryWithContext(context.Background(), NewConstant(0), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+}
+
+// sleepLog is a Sleeper that records the requested durations without
+// waiting.
+type sleepLog []time.Duration
+
+func (s *sleepLog) Sleep(ctx context.Context, d time.Duration) error {
+	*s = append(*s, d)
+	return ctx.Err()
+}
+
+func TestWithLoadGate(t *testing.T) {
+	errFail := errors.New("fail")
+
+	t.Run("waits an extra interval when closed", func(t *testing.T) {
+		var sleeps sleepLog
+		gates := []bool{true, false, true}
+		calls := 0
+		err := RetryWithContext(context.Background(), NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(3)),
+			func(context.Context) error {
+				calls++
+				return errFail
+			},
+			WithSleeper(&sleeps),
+			WithLoadGate(func() bool {
+				open := gates[0]
+				gates = gates[1:]
+				return open
+			}))
+		if !errors.Is(err, errFail) {
+			t.Fatalf("Expected %v, got %v", errFail, err)
+		}
+
+		// The extra wait does not count against max retries
+		if calls != 4 {
+			t.Errorf("Expected 4 calls (1 + 3 retries), got %d", calls)
+		}
+		want := []time.Duration{10, 20, 20, 40}
+		if len(sleeps) != len(want) {
+			t.Fatalf("Expected sleeps %v ms, got %v", want, sleeps)
+		}
+		for i := range want {
+			if sleeps[i] != want[i]*time.Millisecond {
+				t.Errorf("Sleep %d: expected %v, got %v", i, want[i]*time.Millisecond, sleeps[i])
+			}
+		}
+	})
+
+	t.Run("context cancels the extra wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := RetryWithContext(ctx, NewConstant(time.Hour), func(context.Context) error {
+			return errFail
+		}, WithSleeper(noopSleeper{}), WithLoadGate(func() bool {
+			cancel()
+			return false
+		}))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestWithDriftObserver(t *testing.T) {
+	var requested, actual []time.Duration
+	calls := 0
+	err := RetryWithContext(context.Background(), NewConstant(5*time.Millisecond, WithMaxRetries(2)),
+		func(context.Context) error {
+			calls++
+			return errors.New("fail")
+		},
+		WithDriftObserver(func(r, a time.Duration) {
+			requested = append(requested, r)
+			actual = append(actual, a)
+		}))
+	if err == nil {
+		t.Fatal("Expected an error after exhausting retries")
+	}
+
+	if len(requested) != 2 {
+		t.Fatalf("Expected 2 observed waits, got %d", len(requested))
+	}
+	for i := range requested {
+		if requested[i] != 5*time.Millisecond {
+			t.Errorf("Wait %d: expected requested 5ms, got %v", i, requested[i])
+		}
+		if actual[i] < requested[i] {
+			t.Errorf("Wait %d: actual %v shorter than requested %v", i, actual[i], requested[i])
+		}
+	}
+
+	// Interrupted waits are not reported
+	ctx, cancel := context.WithCancel(context.Background())
+	observed := 0
+	_ = RetryWithContext(ctx, NewConstant(time.Hour), func(context.Context) error {
+		cancel()
+		return errors.New("fail")
+	}, WithDriftObserver(func(time.Duration, time.Duration) { observed++ }))
+	if observed != 0 {
+		t.Errorf("Expected no report for an interrupted wait, got %d", observed)
+	}
+}
+
+func TestWithRateLimitReset(t *testing.T) {
+	errLimited := errors.New("rate limited")
+	errFail := errors.New("fail")
+
+	var sleeps sleepLog
+	resets := []time.Time{
+		time.Now().Add(time.Hour),
+		{},                           // not rate limited
+		time.Now().Add(-time.Minute), // already refreshed
+	}
+	calls := 0
+	err := RetryWithContext(context.Background(), NewConstant(10*time.Millisecond, WithMaxRetries(3)),
+		func(context.Context) error {
+			calls++
+			if calls <= len(resets) && resets[calls-1].IsZero() {
+				return errFail
+			}
+			return errLimited
+		},
+		WithSleeper(&sleeps),
+		WithRateLimitReset(func(err error) (time.Time, bool) {
+			return resets[len(sleeps)], errors.Is(err, errLimited)
+		}))
+	if !errors.Is(err, errLimited) {
+		t.Fatalf("Expected %v, got %v", errLimited, err)
+	}
+
+	if len(sleeps) != 3 {
+		t.Fatalf("Expected 3 sleeps, got %v", sleeps)
+	}
+	if sleeps[0] <= 59*time.Minute || sleeps[0] > time.Hour {
+		t.Errorf("Sleep 1: expected to wait until the reset, got %v", sleeps[0])
+	}
+	for i, d := range sleeps[1:] {
+		if d != 10*time.Millisecond {
+			t.Errorf("Sleep %d: expected the computed delay, got %v", i+2, d)
+		}
+	}
+}
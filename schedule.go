@@ -0,0 +1,84 @@
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// TokenSchedule returns a channel that receives a token after each delay
+// of the sequence, e.g. to release work to a rate limiter on a backoff
+// schedule. The channel is closed when the sequence is exhausted or ctx is
+// done, so it can be consumed with a plain range loop.
+//
+// The sequence is advanced by a background goroutine and must not be used
+// elsewhere until the channel is closed. The next delay starts once the
+// previous token has been received. Cancelling ctx stops the underlying
+// timer and releases the goroutine.
+//
+// Example:
+//
+//	for range TokenSchedule(ctx, NewConstant(time.Second, WithMaxRetries(10))) {
+//		sendBatch()
+//	}
+func TokenSchedule(ctx context.Context, s Sequence) <-chan struct{} {
+	return schedule(ctx, s, func(time.Duration) struct{} { return struct{}{} })
+}
+
+// NewTicker returns a channel that receives each delay of the sequence
+// once it has elapsed, like a time.Ticker on a backoff schedule. Unlike
+// TokenSchedule, the values tell how long was waited. The channel is
+// closed when the sequence is exhausted or ctx is done.
+//
+// The delays are waited for with a real timer, so the channel can be used
+// in a select alongside other channels. As with TokenSchedule, the
+// sequence is advanced by a background goroutine and must not be used
+// elsewhere until the channel is closed, and the next delay starts once
+// the previous value has been received.
+//
+// Example:
+//
+//	ticks := NewTicker(ctx, NewExponential(time.Second, 2.0, WithMaxRetries(5)))
+//	for {
+//		select {
+//		case d, ok := <-ticks:
+//			if !ok {
+//				return ErrExhausted
+//			}
+//			log.Printf("polling after %v", d)
+//			poll()
+//		case ev := <-events:
+//			handle(ev)
+//		}
+//	}
+func NewTicker(ctx context.Context, s Sequence) <-chan time.Duration {
+	return schedule(ctx, s, func(d time.Duration) time.Duration { return d })
+}
+
+// schedule sends value(d) on the returned channel after each delay d of s,
+// closing it when s is exhausted or ctx is done.
+func schedule[T any](ctx context.Context, s Sequence, value func(time.Duration) T) <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			d, ok := s.Next()
+			if !ok {
+				return
+			}
+
+			if err := (timerSleeper{}).Sleep(ctx, d); err != nil {
+				return
+			}
+
+			select {
+			case ch <- value(d):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
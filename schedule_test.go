@@ -0,0 +1,68 @@
+package backoff
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTokenSchedule(t *testing.T) {
+	t.Run("emits one token per delay", func(t *testing.T) {
+		tokens := 0
+		for range TokenSchedule(context.Background(), NewConstant(time.Millisecond, WithMaxRetries(5))) {
+			tokens++
+		}
+		if tokens != 5 {
+			t.Errorf("Expected 5 tokens, got %d", tokens)
+		}
+	})
+
+	t.Run("cancel closes the channel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := TokenSchedule(ctx, NewConstant(time.Hour))
+		cancel()
+
+		select {
+		case _, ok := <-ch:
+			if ok {
+				t.Error("Expected no token after cancellation")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected channel to be closed after cancellation")
+		}
+	})
+}
+
+func TestNewTicker(t *testing.T) {
+	t.Run("emits the delays", func(t *testing.T) {
+		start := time.Now()
+		var got []time.Duration
+		for d := range NewTicker(context.Background(), NewExponential(time.Millisecond, 2.0, WithMaxRetries(4))) {
+			got = append(got, d)
+		}
+
+		want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 8 * time.Millisecond}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+		if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+			t.Errorf("Expected the delays to be waited for, took %v", elapsed)
+		}
+	})
+
+	t.Run("cancel closes the channel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := NewTicker(ctx, NewConstant(time.Hour))
+		cancel()
+
+		select {
+		case d, ok := <-ch:
+			if ok {
+				t.Errorf("Expected no value after cancellation, got %v", d)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected channel to be closed after cancellation")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package backoff
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// synchronized guards a Sequence with a mutex. See Synchronized.
+type synchronized struct {
+	mu sync.Mutex
+	s  Sequence
+}
+
+// Synchronized wraps s so that a single instance can be shared between
+// goroutines: every method holds a mutex while it calls into s. The
+// strategies of this package are not safe for concurrent use on their
+// own.
+//
+// The wrapper implements ContextSequence and the accessors of the
+// strategies, Current, Attempts, Elapsed, RemainingRetries and Remaining,
+// delegating to s if it has them. Otherwise they report nothing: (0, false)
+// for Current, 0 for Attempts and Elapsed, -1 for RemainingRetries and
+// math.MaxInt64 for Remaining, i.e. no known limit.
+//
+// Example:
+//
+//	b := Synchronized(NewExponential(100*time.Millisecond, 2.0, WithJitter()))
+//	for range workers {
+//		go func() {
+//			d, ok := b.Next()
+//			// ...
+//		}()
+//	}
+func Synchronized(s Sequence) Sequence {
+	return &synchronized{s: s}
+}
+
+// Next returns the next delay of the wrapped sequence.
+func (s *synchronized) Next() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Next()
+}
+
+// Reset resets the wrapped sequence.
+func (s *synchronized) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Reset()
+}
+
+// NextContext is like Next, but returns (0, false) without advancing the
+// wrapped sequence if ctx is done.
+func (s *synchronized) NextContext(ctx context.Context) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cs, ok := s.s.(ContextSequence); ok {
+		return cs.NextContext(ctx)
+	}
+	if ctx.Err() != nil {
+		return 0, false
+	}
+	return s.s.Next()
+}
+
+// Current returns the delay produced by the last call to Next of the
+// wrapped sequence.
+func (s *synchronized) Current() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.s.(interface{ Current() (time.Duration, bool) }); ok {
+		return c.Current()
+	}
+	return 0, false
+}
+
+// Attempts returns the number of retries of the wrapped sequence so far.
+func (s *synchronized) Attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.s.(interface{ Attempts() int }); ok {
+		return a.Attempts()
+	}
+	return 0
+}
+
+// Elapsed returns the elapsed time of the wrapped sequence.
+func (s *synchronized) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.s.(interface{ Elapsed() time.Duration }); ok {
+		return e.Elapsed()
+	}
+	return 0
+}
+
+// RemainingRetries returns the number of retries left of the wrapped
+// sequence, or -1 if unlimited or unknown.
+func (s *synchronized) RemainingRetries() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.s.(interface{ RemainingRetries() int }); ok {
+		return r.RemainingRetries()
+	}
+	return -1
+}
+
+// Remaining returns the elapsed budget left of the wrapped sequence, or
+// math.MaxInt64 if unlimited or unknown.
+func (s *synchronized) Remaining() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.s.(interface{ Remaining() time.Duration }); ok {
+		return r.Remaining()
+	}
+	return math.MaxInt64
+}
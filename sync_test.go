@@ -0,0 +1,103 @@
+package backoff
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSynchronized(t *testing.T) {
+	t.Run("concurrent use", func(t *testing.T) {
+		const goroutines, calls = 16, 100
+		s := Synchronized(NewExponential(time.Millisecond, 2.0,
+			WithJitter(),
+			WithMaxInterval(time.Second),
+			WithMaxRetries(goroutines*calls)))
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < calls; j++ {
+					if _, ok := s.Next(); !ok {
+						t.Error("Unexpected exhaustion")
+						return
+					}
+					s.(interface{ Attempts() int }).Attempts()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := s.(interface{ Attempts() int }).Attempts(); got != goroutines*calls {
+			t.Errorf("Expected %d attempts, got %d", goroutines*calls, got)
+		}
+		if _, ok := s.Next(); ok {
+			t.Error("Expected exhaustion after all retries were used")
+		}
+	})
+
+	t.Run("delegates accessors", func(t *testing.T) {
+		s := Synchronized(NewConstant(time.Second, WithMaxRetries(3), WithMaxElapsed(time.Minute)))
+		s.Next()
+
+		type accessors interface {
+			ContextSequence
+			Current() (time.Duration, bool)
+			Attempts() int
+			Elapsed() time.Duration
+			RemainingRetries() int
+			Remaining() time.Duration
+		}
+		a := s.(accessors)
+		if d, ok := a.Current(); !ok || d != time.Second {
+			t.Errorf("Expected Current (1s, true), got (%v, %v)", d, ok)
+		}
+		if a.Attempts() != 1 || a.Elapsed() != time.Second || a.RemainingRetries() != 2 || a.Remaining() != 59*time.Second {
+			t.Errorf("Unexpected accessors: attempts=%d elapsed=%v retries=%d remaining=%v",
+				a.Attempts(), a.Elapsed(), a.RemainingRetries(), a.Remaining())
+		}
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, ok := a.NextContext(cancelled); ok || a.Attempts() != 1 {
+			t.Error("Expected NextContext to stop without consuming an attempt")
+		}
+
+		s.Reset()
+		if a.Attempts() != 0 {
+			t.Errorf("Expected 0 attempts after Reset, got %d", a.Attempts())
+		}
+	})
+
+	t.Run("without accessors", func(t *testing.T) {
+		s := Synchronized(OnExhausted(NewConstant(time.Second), func(int, time.Duration) {})).(interface {
+			ContextSequence
+			Current() (time.Duration, bool)
+			Attempts() int
+			RemainingRetries() int
+			Remaining() time.Duration
+		})
+		s.Next()
+
+		if _, ok := s.Current(); ok {
+			t.Error("Expected no current delay")
+		}
+		if s.Attempts() != 0 || s.RemainingRetries() != -1 || s.Remaining() != math.MaxInt64 {
+			t.Errorf("Expected defaults, got attempts=%d retries=%d remaining=%v",
+				s.Attempts(), s.RemainingRetries(), s.Remaining())
+		}
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, ok := s.NextContext(cancelled); ok {
+			t.Error("Expected NextContext to stop with a cancelled context")
+		}
+		if d, ok := s.NextContext(context.Background()); !ok || d != time.Second {
+			t.Errorf("Expected (1s, true), got (%v, %v)", d, ok)
+		}
+	})
+}
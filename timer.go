@@ -0,0 +1,78 @@
+package backoff
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Timer is a started timer for a single delay of a sequence, bound to a
+// context. See NextC.
+type Timer struct {
+	// C receives the current time once the delay has elapsed. It never
+	// receives if the timer is stopped or its context is done first.
+	C <-chan time.Time
+
+	mu      sync.Mutex  // held until the timer is fully set up
+	timer   *time.Timer // fires after the delay
+	stopCtx func() bool // unregisters the context callback
+}
+
+// NextC advances s and returns a started timer for the next delay, like
+// time.After but cancellable, for use in select loops. Returns nil and
+// false if s is exhausted, or if ctx is already done, in which case s is
+// not advanced. It is a lower-level primitive than the retry helpers: the
+// caller selects on the timer's channel and on ctx.Done().
+//
+// The timer is owned by the library: it is stopped when ctx is done, and
+// all resources are released once it fires. A caller that abandons the
+// timer for another reason, e.g. because a different case of its select
+// won, should call Stop so that it does not wait for ctx to be done.
+//
+// Example:
+//
+//	for {
+//		t, ok := NextC(ctx, b)
+//		if !ok {
+//			return ErrExhausted
+//		}
+//		select {
+//		case <-t.C:
+//			poll()
+//		case ev := <-events:
+//			t.Stop()
+//			handle(ev)
+//		case <-ctx.Done():
+//			return ctx.Err()
+//		}
+//	}
+func NextC(ctx context.Context, s Sequence) (*Timer, bool) {
+	d, ok := nextContext(ctx, s)
+	if !ok {
+		return nil, false
+	}
+
+	c := make(chan time.Time, 1)
+	t := &Timer{C: c}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timer = time.AfterFunc(d, func() {
+		t.mu.Lock()
+		t.stopCtx()
+		t.mu.Unlock()
+		c <- time.Now()
+	})
+	t.stopCtx = context.AfterFunc(ctx, func() { t.timer.Stop() })
+
+	return t, true
+}
+
+// Stop prevents the timer from firing and releases its resources. It
+// returns true if the call stops the timer, false if the timer has
+// already fired or been stopped, including by its context.
+func (t *Timer) Stop() bool {
+	t.stopCtx()
+	return t.timer.Stop()
+}
@@ -0,0 +1,76 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNextC(t *testing.T) {
+	t.Run("fires after the delay", func(t *testing.T) {
+		c := NewConstant(10*time.Millisecond, WithMaxRetries(2))
+
+		for i := 0; i < 2; i++ {
+			start := time.Now()
+			timer, ok := NextC(context.Background(), c)
+			if !ok {
+				t.Fatalf("Call %d: expected a timer", i+1)
+			}
+			select {
+			case <-timer.C:
+				if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+					t.Errorf("Expected the timer to fire after 10ms, got %v", elapsed)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected the timer to fire")
+			}
+			if timer.Stop() {
+				t.Error("Expected Stop to report false after the timer fired")
+			}
+		}
+
+		if timer, ok := NextC(context.Background(), c); ok || timer != nil {
+			t.Errorf("Expected (nil, false) when exhausted, got (%v, %v)", timer, ok)
+		}
+	})
+
+	t.Run("context done stops the timer", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		timer, _ := NextC(ctx, NewConstant(20*time.Millisecond))
+		cancel()
+
+		select {
+		case <-timer.C:
+			t.Error("Expected no tick after the context is done")
+		case <-time.After(50 * time.Millisecond):
+		}
+		if timer.Stop() {
+			t.Error("Expected the timer to be stopped already")
+		}
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c := NewConstant(time.Second)
+		if timer, ok := NextC(ctx, c); ok || timer != nil {
+			t.Errorf("Expected (nil, false), got (%v, %v)", timer, ok)
+		}
+		if got := c.Attempts(); got != 0 {
+			t.Errorf("Expected the sequence not to advance, got %d attempts", got)
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		timer, _ := NextC(context.Background(), NewConstant(20*time.Millisecond))
+		if !timer.Stop() {
+			t.Error("Expected Stop to stop a pending timer")
+		}
+
+		select {
+		case <-timer.C:
+			t.Error("Expected no tick after Stop")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package backoff
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"strconv"
+	"time"
+)
+
+// tracer wraps a Sequence and writes each delay to a CSV writer. See
+// Trace.
+type tracer struct {
+	Sequence
+	w   io.Writer
+	csv *csv.Writer
+
+	attempt int  // successful calls to Next since Reset
+	header  bool // whether the header row has been written
+}
+
+// Trace wraps s so that every delay it returns is written to w as a CSV
+// row "attempt,delay_ns", preceded once by a header row. Attempts are
+// numbered from 1 and restart at 1 after Reset(). When s is exhausted,
+// w is flushed if it has a Flush() error method, as bufio.Writer does.
+//
+// Trace is meant for ad-hoc capture, e.g. during load tests. A failed
+// write is logged with the standard logger and otherwise ignored; it never
+// affects the delays returned.
+//
+// Example:
+//
+//	f, _ := os.Create("delays.csv")
+//	defer f.Close()
+//	b := Trace(NewExponential(100*time.Millisecond, 2.0, WithJitter()), f)
+func Trace(s Sequence, w io.Writer) Sequence {
+	return &tracer{Sequence: s, w: w, csv: csv.NewWriter(w)}
+}
+
+// Next returns the next delay of the wrapped sequence, recording it.
+func (t *tracer) Next() (time.Duration, bool) {
+	d, ok := t.Sequence.Next()
+	if !ok {
+		if f, ok := t.w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				log.Printf("backoff: trace: %v", err)
+			}
+		}
+		return d, false
+	}
+
+	t.attempt++
+	if !t.header {
+		t.header = true
+		t.write("attempt", "delay_ns")
+	}
+	t.write(strconv.Itoa(t.attempt), strconv.FormatInt(int64(d), 10))
+	return d, true
+}
+
+// Reset resets the wrapped sequence and restarts the attempt count.
+func (t *tracer) Reset() {
+	t.Sequence.Reset()
+	t.attempt = 0
+}
+
+// write writes one row, logging any error.
+func (t *tracer) write(record ...string) {
+	t.csv.Write(record)
+	t.csv.Flush()
+	if err := t.csv.Error(); err != nil {
+		log.Printf("backoff: trace: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package backoff
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrace(t *testing.T) {
+	t.Run("writes a row per delay", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		s := Trace(NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(3)), w)
+
+		for {
+			if _, ok := s.Next(); !ok {
+				break
+			}
+		}
+
+		want := "attempt,delay_ns\n1,10000000\n2,20000000\n3,40000000\n"
+		if got := buf.String(); got != want {
+			t.Errorf("Expected flushed output on exhaustion:\n%s\ngot:\n%s", want, got)
+		}
+
+		// Reset restarts the attempt count, without a second header
+		buf.Reset()
+		s.Reset()
+		s.Next()
+		w.Flush()
+		if got := buf.String(); got != "1,10000000\n" {
+			t.Errorf("Expected attempts to restart after Reset(), got %q", got)
+		}
+	})
+
+	t.Run("write errors do not affect delays", func(t *testing.T) {
+		var logs bytes.Buffer
+		prev := log.Writer()
+		log.SetOutput(&logs)
+		defer log.SetOutput(prev)
+
+		s := Trace(NewConstant(time.Second, WithMaxRetries(2)), failingWriter{})
+		for i := 0; i < 2; i++ {
+			if d, ok := s.Next(); !ok || d != time.Second {
+				t.Fatalf("Expected (1s, true) on call %d, got (%v, %v)", i+1, d, ok)
+			}
+		}
+		if _, ok := s.Next(); ok {
+			t.Error("Expected sequence to be exhausted")
+		}
+		if !strings.Contains(logs.String(), "backoff: trace") {
+			t.Error("Expected write error to be logged")
+		}
+	})
+}
+
+// failingWriter is an io.Writer whose writes always fail.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -0,0 +1,259 @@
+package backoff
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// WeightedDelay is a candidate delay for WeightedRandom together with its
+// relative weight.
+type WeightedDelay struct {
+	Delay  time.Duration // candidate delay
+	Weight float64       // relative weight, non-positive weights are never picked
+}
+
+// WeightedRandom implements a backoff strategy that picks each delay at
+// random from a fixed set of candidates, proportionally to their weights.
+//
+// Use WeightedRandom for deliberately multimodal retry timing, e.g. to
+// spread load across a few well-known delays or for chaos testing.
+type WeightedRandom struct {
+	options *options
+	choices []WeightedDelay // candidate delays
+	total   float64         // sum of positive weights
+
+	retries int           // current retry count
+	elapsed time.Duration // total elapsed time
+	last    time.Duration // delay returned by the last call to Next
+	hasLast bool          // whether last holds a valid delay
+}
+
+// NewWeightedRandom creates a new weighted random backoff strategy.
+//
+// Parameters:
+//   - choices: The candidate delays and their relative weights
+//   - opts: Optional configuration functions
+//
+// If no choice has a positive weight, Next() always returns (0, false).
+//
+// Example:
+//
+//	// Mostly 100ms, sometimes 1s, rarely 5s
+//	w := NewWeightedRandom([]WeightedDelay{
+//		{Delay: 100 * time.Millisecond, Weight: 0.8},
+//		{Delay: time.Second, Weight: 0.15},
+//		{Delay: 5 * time.Second, Weight: 0.05},
+//	}, WithMaxRetries(10))
+func NewWeightedRandom(choices []WeightedDelay, opts ...Option) *WeightedRandom {
+	o := applyOptions(opts)
+
+	var total float64
+	for _, c := range choices {
+		if c.Weight > 0 {
+			total += c.Weight
+		}
+	}
+
+	w := &WeightedRandom{
+		options: o,
+		choices: append([]WeightedDelay(nil), choices...),
+		total:   total,
+		elapsed: o.elapsedOffset,
+	}
+	w.skip(o.startSkip())
+
+	return w
+}
+
+// Next returns a delay picked at random from the candidates, subject to
+// jitter and min/max bounds.
+//
+// Returns:
+//   - time.Duration: The picked delay duration
+//   - bool: true if more retries are allowed, false if limits are reached
+func (w *WeightedRandom) Next() (time.Duration, bool) {
+	if w.total <= 0 || w.options.outOfRetries(w.retries) {
+		w.last, w.hasLast = 0, false
+		return 0, false
+	}
+
+	d := w.pick()
+	d = w.options.applyJitter(d, w.elapsed)
+	d = applyBounds(d, w.options.minInterval, w.options.maxAt(w.retries))
+	d = w.options.firstRange(d, w.retries)
+	d = w.options.scaled(d)
+	if w.options.maxElapsed > 0 && !w.options.free(w.retries) && w.options.spent(w.elapsed)+d >= w.options.maxElapsed {
+		w.last, w.hasLast = 0, false
+		return 0, false
+	}
+
+	if !w.options.acquire() {
+		w.last, w.hasLast = 0, false
+		return 0, false
+	}
+
+	d = w.options.finish(d)
+	w.elapsed += w.options.charge(w.retries, d)
+	w.retries++
+	w.last, w.hasLast = d, true
+	return d, true
+}
+
+// NextContext is like Next, but returns (0, false) without picking a delay
+// if ctx is done.
+func (w *WeightedRandom) NextContext(ctx context.Context) (time.Duration, bool) {
+	return nextContext(ctx, w)
+}
+
+// skip advances the retry count by n steps, as WithRandomStart does at
+// construction.
+func (w *WeightedRandom) skip(n int) {
+	w.retries += n
+}
+
+// JitterSpread returns the standard deviation of the next delay of w,
+// which includes the random choice among the candidates besides jitter;
+// see Common methods.
+func (w *WeightedRandom) JitterSpread(n int) time.Duration {
+	return jitterSpread(w, n)
+}
+
+// DistinctDelays returns the number of unique values among the next n
+// delays of w, at most the number of candidates without jitter; see Common
+// methods.
+func (w *WeightedRandom) DistinctDelays(n int) int {
+	return distinctDelays(w, n)
+}
+
+// fork returns a copy of the weighted random backoff including its state.
+func (w *WeightedRandom) fork(mod func(*options)) Sequence {
+	f := *w
+	f.options = forkOptions(w.options, mod)
+	return &f
+}
+
+// pick returns a candidate delay chosen proportionally to its weight.
+// If the random number generator fails, the first candidate with a
+// positive weight is returned.
+func (w *WeightedRandom) pick() time.Duration {
+	f, _ := RandFloat(w.options.rand)
+	x := f * w.total
+
+	var picked time.Duration
+	for _, c := range w.choices {
+		if c.Weight <= 0 {
+			continue
+		}
+		picked = c.Delay
+		if x < c.Weight {
+			break
+		}
+		x -= c.Weight
+	}
+	return picked
+}
+
+// Reset resets the weighted random backoff to its initial state.
+// This clears the retry count and the delay reported by Current, and
+// restores the elapsed time to its initial offset.
+func (w *WeightedRandom) Reset() {
+	if !w.options.allowReset() {
+		return
+	}
+	w.retries = 0
+	w.elapsed = w.options.elapsedOffset
+	w.last = 0
+	w.hasLast = false
+	w.options.reset()
+}
+
+// SoftReset halves the retry count, rounded down, and clears the delay
+// reported by Current. Unlike Reset, the elapsed time is kept. Since the
+// delays do not depend on the retry count, this only gives retries back.
+func (w *WeightedRandom) SoftReset() {
+	w.retries /= 2
+	w.last = 0
+	w.hasLast = false
+}
+
+// Current returns the delay produced by the last call to Next without
+// advancing the sequence.
+//
+// Returns (0, false) if Next has not been called since construction or
+// Reset, or if the last call to Next returned false.
+func (w *WeightedRandom) Current() (time.Duration, bool) {
+	return w.last, w.hasLast
+}
+
+// Attempts returns the number of delays picked since construction or
+// Reset, less those rewound by SoftReset.
+func (w *WeightedRandom) Attempts() int {
+	return w.retries
+}
+
+// Elapsed returns the sum of the delays picked so far, plus the offset set
+// with WithElapsedOffset.
+func (w *WeightedRandom) Elapsed() time.Duration {
+	return w.elapsed
+}
+
+// RemainingRetries returns the retries w has left under WithMaxRetries,
+// e.g. to log "attempt 3 of 5", or -1 if retries are unlimited.
+func (w *WeightedRandom) RemainingRetries() int {
+	return remainingRetries(w.options, w.retries)
+}
+
+// budget returns the configured maxElapsed and the elapsed time charged
+// against it so far.
+func (w *WeightedRandom) budget() (limit, spent time.Duration) {
+	return w.options.maxElapsed, w.options.spent(w.elapsed)
+}
+
+// Remaining returns the elapsed budget w has left under WithMaxElapsed, or
+// math.MaxInt64 without a time limit. A result of 0 means the budget is
+// used up.
+func (w *WeightedRandom) Remaining() time.Duration {
+	return remaining(w)
+}
+
+// Derive returns a weighted random backoff with the same choices and
+// options that is charged with the elapsed time of w, so that a nested
+// operation stops at the same deadline.
+func (w *WeightedRandom) Derive() *WeightedRandom {
+	return derive(w, w)
+}
+
+// Clone returns an independent copy of w. With the default random source,
+// the copy picks the same delays w would.
+func (w *WeightedRandom) Clone() *WeightedRandom {
+	return clone(w)
+}
+
+// Iter returns an iterator over the next delays picked by w, see Iterate.
+func (w *WeightedRandom) Iter() iter.Seq[time.Duration] {
+	return Iterate(w)
+}
+
+// IterSchedule is like Iter, but also yields the time at which each delay
+// of w ends, counted from now or, if now is zero, from the time of the
+// Clock set with WithClock. It stops early when ctx is done.
+func (w *WeightedRandom) IterSchedule(ctx context.Context, now time.Time) iter.Seq2[time.Duration, time.Time] {
+	return iterSchedule(ctx, w, w.options, now)
+}
+
+// Describe returns the configuration of the weighted random backoff.
+// Choices is a copy and may be modified freely.
+func (w *WeightedRandom) Describe() Description {
+	return w.options.describe(Description{
+		Kind:    KindWeighted,
+		Choices: append([]WeightedDelay(nil), w.choices...),
+	})
+}
+
+// WithOverrides returns a new weighted random backoff with the same
+// choices and options, plus opts applied on top, starting from its initial
+// state.
+func (w *WeightedRandom) WithOverrides(opts ...Option) Sequence {
+	return NewWeightedRandom(w.choices, w.options.with(opts)...)
+}
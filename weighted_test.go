@@ -0,0 +1,72 @@
+package backoff
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestWeightedRandom(t *testing.T) {
+	t.Run("samples proportionally", func(t *testing.T) {
+		w := NewWeightedRandom([]WeightedDelay{
+			{Delay: 10 * time.Millisecond, Weight: 3},
+			{Delay: 20 * time.Millisecond, Weight: 1},
+			{Delay: 30 * time.Millisecond, Weight: 0},
+		}, WithRandSource(rand.NewPCG(42, 1024)))
+
+		counts := make(map[time.Duration]int)
+		const samples = 10000
+		for i := 0; i < samples; i++ {
+			d, ok := w.Next()
+			if !ok {
+				t.Fatalf("Next() returned false on call %d", i+1)
+			}
+			counts[d]++
+		}
+
+		if counts[30*time.Millisecond] != 0 {
+			t.Errorf("Zero weight delay was picked %d times", counts[30*time.Millisecond])
+		}
+		ratio := float64(counts[10*time.Millisecond]) / samples
+		if ratio < 0.72 || ratio > 0.78 {
+			t.Errorf("Expected about 75%% of 10ms delays, got %.2f", ratio)
+		}
+		if len(counts) != 2 {
+			t.Errorf("Expected only the two weighted delays, got %v", counts)
+		}
+	})
+
+	t.Run("honors limits", func(t *testing.T) {
+		w := NewWeightedRandom([]WeightedDelay{{Delay: 100 * time.Millisecond, Weight: 1}},
+			WithMaxRetries(5), WithMaxElapsed(250*time.Millisecond))
+
+		attempts := 0
+		for {
+			if _, ok := w.Next(); !ok {
+				break
+			}
+			attempts++
+		}
+		if attempts != 2 {
+			t.Errorf("Expected 2 attempts within the elapsed budget, got %d", attempts)
+		}
+
+		w.Reset()
+		if _, ok := w.Next(); !ok {
+			t.Error("Next() should succeed after Reset()")
+		}
+	})
+
+	t.Run("no positive weights", func(t *testing.T) {
+		w := NewWeightedRandom([]WeightedDelay{{Delay: time.Second, Weight: 0}})
+		if d, ok := w.Next(); ok || d != 0 {
+			t.Errorf("Expected (0, false) without positive weights, got (%v, %v)", d, ok)
+		}
+		if d, ok := w.Current(); ok || d != 0 {
+			t.Errorf("Expected Current() to report (0, false) without positive weights, got (%v, %v)", d, ok)
+		}
+		if _, ok := NewWeightedRandom(nil).Next(); ok {
+			t.Error("Expected Next() to fail without choices")
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package backoff
+
+import "time"
+
+// exhaustNotifier wraps a Sequence and invokes a callback when it is
+// exhausted. See OnExhausted.
+type exhaustNotifier struct {
+	Sequence
+	cb func(attempts int, elapsed time.Duration)
+
+	attempts int           // successful calls to Next since Reset
+	elapsed  time.Duration // sum of delays returned since Reset
+	fired    bool          // whether cb has been called since Reset
+}
+
+// OnExhausted wraps s so that cb is called exactly once, the first time
+// Next() returns false. The callback receives the number of delays
+// returned and their sum, which makes it a single place to emit a
+// "giving up" event for any strategy. Reset() rearms the callback.
+//
+// Example:
+//
+//	b := OnExhausted(NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(5)),
+//		func(attempts int, elapsed time.Duration) {
+//			log.Printf("giving up after %d retries (%v)", attempts, elapsed)
+//		})
+func OnExhausted(s Sequence, cb func(attempts int, elapsed time.Duration)) Sequence {
+	return &exhaustNotifier{Sequence: s, cb: cb}
+}
+
+// Next returns the next delay of the wrapped sequence, calling the
+// callback the first time it returns false.
+func (n *exhaustNotifier) Next() (time.Duration, bool) {
+	d, ok := n.Sequence.Next()
+	if ok {
+		n.attempts++
+		n.elapsed += d
+		return d, true
+	}
+
+	if !n.fired {
+		n.fired = true
+		n.cb(n.attempts, n.elapsed)
+	}
+	return d, false
+}
+
+// Reset resets the wrapped sequence and rearms the callback.
+func (n *exhaustNotifier) Reset() {
+	n.Sequence.Reset()
+	n.attempts = 0
+	n.elapsed = 0
+	n.fired = false
+}
+
+// switcher delegates to a primary Sequence until a condition is met, then
+// to a secondary one. See SwitchWhen.
+type switcher struct {
+	primary   Sequence
+	secondary Sequence
+	condition func(lastDelay time.Duration, attempt int) bool
+
+	attempts int  // delays returned by primary since Reset
+	switched bool // whether secondary is in use
+}
+
+// SwitchWhen returns a Sequence that delegates to primary until condition
+// returns true, then to secondary. The condition is evaluated after every
+// delay returned by primary, with that delay and the number of delays
+// returned so far, starting at 1. The switch takes effect from the next
+// call to Next() on and is never reverted until Reset().
+//
+// If primary is exhausted before the condition is met, the sequence is
+// exhausted too. Reset() resets both sequences and switches back to
+// primary.
+//
+// Example:
+//
+//	// Decorrelated for herd protection, then a plain constant at the cap
+//	capped := 10 * time.Second
+//	b := SwitchWhen(
+//		NewDecorrelated(100*time.Millisecond, 3.0, WithMaxInterval(capped)),
+//		func(last time.Duration, _ int) bool { return last >= capped },
+//		NewConstant(capped))
+func SwitchWhen(primary Sequence, condition func(lastDelay time.Duration, attempt int) bool, secondary Sequence) Sequence {
+	return &switcher{primary: primary, secondary: secondary, condition: condition}
+}
+
+// Next returns the next delay of the active sequence.
+func (s *switcher) Next() (time.Duration, bool) {
+	if s.switched {
+		return s.secondary.Next()
+	}
+
+	d, ok := s.primary.Next()
+	if !ok {
+		return d, false
+	}
+	s.attempts++
+	if s.condition(d, s.attempts) {
+		s.switched = true
+	}
+	return d, true
+}
+
+// Reset resets both sequences and switches back to the primary one.
+func (s *switcher) Reset() {
+	s.primary.Reset()
+	s.secondary.Reset()
+	s.attempts = 0
+	s.switched = false
+}
+
+// summer returns the elementwise sum of two sequences. See Sum.
+type summer struct {
+	a, b Sequence
+}
+
+// Sum returns a Sequence whose delays are the sums of the delays of a and
+// b, e.g. to layer growth on top of a guaranteed base pacing. Each call to
+// Next() advances both sequences; the sum is exhausted as soon as either
+// of them is. Sums beyond the range of time.Duration saturate at its
+// maximum. Reset() resets both sequences.
+//
+// Example:
+//
+//	// Always at least 1s, plus exponential growth on top
+//	b := Sum(
+//		NewConstant(time.Second),
+//		NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(8)))
+func Sum(a, b Sequence) Sequence {
+	return &summer{a: a, b: b}
+}
+
+// Next returns the sum of the next delays of both sequences.
+func (s *summer) Next() (time.Duration, bool) {
+	da, ok := s.a.Next()
+	if !ok {
+		return 0, false
+	}
+	db, ok := s.b.Next()
+	if !ok {
+		return 0, false
+	}
+	return addDuration(da, db), true
+}
+
+// Reset resets both sequences.
+func (s *summer) Reset() {
+	s.a.Reset()
+	s.b.Reset()
+}
@@ -0,0 +1,157 @@
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOnExhausted(t *testing.T) {
+	var calls, gotAttempts int
+	var gotElapsed time.Duration
+	s := OnExhausted(NewExponential(10*time.Millisecond, 2.0, WithMaxRetries(3)),
+		func(attempts int, elapsed time.Duration) {
+			calls++
+			gotAttempts = attempts
+			gotElapsed = elapsed
+		})
+
+	for i := 0; i < 3; i++ {
+		if _, ok := s.Next(); !ok {
+			t.Fatalf("Next() returned false on call %d", i+1)
+		}
+	}
+	if calls != 0 {
+		t.Fatal("Callback should not fire before exhaustion")
+	}
+
+	s.Next()
+	s.Next()
+	if calls != 1 {
+		t.Errorf("Expected callback to fire exactly once, got %d", calls)
+	}
+	if gotAttempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", gotAttempts)
+	}
+	if gotElapsed != 70*time.Millisecond {
+		t.Errorf("Expected 70ms elapsed, got %v", gotElapsed)
+	}
+
+	// Reset rearms the callback
+	s.Reset()
+	for {
+		if _, ok := s.Next(); !ok {
+			break
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected callback to fire again after Reset(), got %d calls", calls)
+	}
+}
+
+func TestSwitchWhen(t *testing.T) {
+	t.Run("switches when condition is met", func(t *testing.T) {
+		var attempts []int
+		s := SwitchWhen(
+			NewExponential(10*time.Millisecond, 2.0),
+			func(last time.Duration, attempt int) bool {
+				attempts = append(attempts, attempt)
+				return last >= 40*time.Millisecond
+			},
+			NewConstant(time.Second, WithMaxRetries(2)))
+
+		expected := []time.Duration{
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+			40 * time.Millisecond, // triggers the switch
+			time.Second,
+			time.Second,
+		}
+		for i, want := range expected {
+			d, ok := s.Next()
+			if !ok || d != want {
+				t.Errorf("Call %d: expected (%v, true), got (%v, %v)", i+1, want, d, ok)
+			}
+		}
+		if _, ok := s.Next(); ok {
+			t.Error("Expected secondary's exhaustion to end the sequence")
+		}
+
+		if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+			t.Errorf("Expected condition to be evaluated for attempts 1 to 3, got %v", attempts)
+		}
+	})
+
+	t.Run("primary exhausted first", func(t *testing.T) {
+		s := SwitchWhen(
+			NewConstant(10*time.Millisecond, WithMaxRetries(1)),
+			func(time.Duration, int) bool { return false },
+			NewConstant(time.Second))
+
+		s.Next()
+		if _, ok := s.Next(); ok {
+			t.Error("Expected exhaustion without switching")
+		}
+	})
+
+	t.Run("reset rewinds to primary", func(t *testing.T) {
+		s := SwitchWhen(
+			NewConstant(10*time.Millisecond),
+			func(_ time.Duration, attempt int) bool { return attempt == 1 },
+			NewConstant(time.Second))
+
+		s.Next()
+		if d, _ := s.Next(); d != time.Second {
+			t.Fatalf("Expected switch after the first attempt, got %v", d)
+		}
+
+		s.Reset()
+		if d, _ := s.Next(); d != 10*time.Millisecond {
+			t.Errorf("Expected primary after Reset(), got %v", d)
+		}
+	})
+}
+
+func TestSum(t *testing.T) {
+	t.Run("adds the delays", func(t *testing.T) {
+		s := Sum(NewConstant(time.Second), NewExponential(100*time.Millisecond, 2.0, WithMaxRetries(3)))
+
+		expected := []time.Duration{
+			1100 * time.Millisecond,
+			1200 * time.Millisecond,
+			1400 * time.Millisecond,
+		}
+		for i, want := range expected {
+			d, ok := s.Next()
+			if !ok || d != want {
+				t.Errorf("Call %d: expected (%v, true), got (%v, %v)", i+1, want, d, ok)
+			}
+		}
+		if _, ok := s.Next(); ok {
+			t.Error("Expected the exhaustion of b to end the sum")
+		}
+
+		s.Reset()
+		if d, ok := s.Next(); !ok || d != 1100*time.Millisecond {
+			t.Errorf("Expected (1.1s, true) after Reset(), got (%v, %v)", d, ok)
+		}
+	})
+
+	t.Run("a exhausted first", func(t *testing.T) {
+		s := Sum(NewConstant(time.Second, WithMaxRetries(1)), NewConstant(time.Second))
+
+		s.Next()
+		if _, ok := s.Next(); ok {
+			t.Error("Expected the exhaustion of a to end the sum")
+		}
+	})
+
+	t.Run("saturates", func(t *testing.T) {
+		huge := time.Duration(math.MaxInt64 - 1)
+		s := Sum(NewConstant(huge), NewConstant(time.Second))
+
+		if d, _ := s.Next(); d != time.Duration(math.MaxInt64) {
+			t.Errorf("Expected the sum to saturate at %v, got %v", time.Duration(math.MaxInt64), d)
+		}
+	})
+}